@@ -0,0 +1,250 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Aggregate is an error that collects many errors. It is safe for
+// concurrent use.
+//
+// An Aggregate must not be copied after first use.
+type Aggregate struct {
+	// mu protects errs.
+	mu sync.RWMutex
+
+	// errs are the collected errors.
+	errs []error
+}
+
+// NewAggregate creates a new Aggregate.
+//
+// Parameters:
+//   - errs: The initial errors. Nil errors are ignored.
+//
+// Returns:
+//   - *Aggregate: A pointer to the new Aggregate. Never returns nil.
+func NewAggregate(errs ...error) *Aggregate {
+	agg := &Aggregate{}
+
+	for _, err := range errs {
+		agg.Add(err)
+	}
+
+	return agg
+}
+
+// Add adds an error to the aggregate. Does nothing if the receiver or
+// err is nil.
+//
+// Parameters:
+//   - err: The error to add.
+func (a *Aggregate) Add(err error) {
+	if a == nil || err == nil {
+		return
+	}
+
+	if e, ok := err.(*Err); ok && e == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.errs = append(a.errs, err)
+}
+
+// Len returns the number of errors in the aggregate.
+//
+// Returns:
+//   - int: The number of errors. 0 if the receiver is nil.
+func (a *Aggregate) Len() int {
+	if a == nil {
+		return 0
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return len(a.errs)
+}
+
+// Errors returns the errors of the aggregate.
+//
+// Returns:
+//   - []error: A copy of the errors, in insertion order. Nil if there are
+//     none.
+func (a *Aggregate) Errors() []error {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if len(a.errs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(a.errs))
+	copy(errs, a.errs)
+
+	return errs
+}
+
+// Unwrap returns the errors of the aggregate so that errors.Is and
+// errors.As can walk through each of them.
+//
+// Returns:
+//   - []error: The errors of the aggregate.
+func (a *Aggregate) Unwrap() []error {
+	return a.Errors()
+}
+
+// Error implements the error interface.
+//
+// Format:
+//
+//	<n> errors occurred:
+//	- <error 1>
+//	- <error 2>
+//	...
+//
+// If the aggregate has a single error, its message is returned as-is.
+func (a *Aggregate) Error() string {
+	errs := a.Errors()
+
+	switch len(errs) {
+	case 0:
+		return "no errors occurred"
+	case 1:
+		return errs[0].Error()
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(strconv.Itoa(len(errs)))
+	builder.WriteString(" errors occurred:")
+
+	for _, err := range errs {
+		builder.WriteString("\n- ")
+		builder.WriteString(err.Error())
+	}
+
+	return builder.String()
+}
+
+// IsNil implements the Pointer interface.
+func (a *Aggregate) IsNil() bool {
+	return a == nil
+}
+
+// ErrorOrNil returns the aggregate as an error only if it is not empty.
+//
+// Returns:
+//   - error: The aggregate, or nil if it has no errors.
+func (a *Aggregate) ErrorOrNil() error {
+	if a.Len() == 0 {
+		return nil
+	}
+
+	return a
+}
+
+// severity_of returns the severity level of the error.
+//
+// Parameters:
+//   - err: The error. Assumed to be non-nil.
+//
+// Returns:
+//   - SeverityLevel: The severity level of the error. Errors that are not
+//     *Err are considered of severity ERROR.
+func severity_of(err error) SeverityLevel {
+	switch x := err.(type) {
+	case *Err:
+		return x.Severity
+	case *Aggregate:
+		level, ok := x.Severity()
+		if ok {
+			return level
+		}
+	}
+
+	return ERROR
+}
+
+// Severity returns the highest severity level among the errors of the
+// aggregate.
+//
+// Returns:
+//   - SeverityLevel: The highest severity level.
+//   - bool: True if the aggregate has at least one error, false otherwise.
+//
+// Errors that are not *Err are considered of severity ERROR.
+func (a *Aggregate) Severity() (SeverityLevel, bool) {
+	errs := a.Errors()
+	if len(errs) == 0 {
+		return INFO, false
+	}
+
+	level := severity_of(errs[0])
+
+	for _, err := range errs[1:] {
+		level = max(level, severity_of(err))
+	}
+
+	return level, true
+}
+
+// Filter returns a new aggregate with the errors that satisfy the predicate.
+//
+// Parameters:
+//   - pred: The predicate to satisfy.
+//
+// Returns:
+//   - *Aggregate: A pointer to the new Aggregate. Never returns nil.
+func (a *Aggregate) Filter(pred func(err error) bool) *Aggregate {
+	filtered := &Aggregate{}
+
+	if pred == nil {
+		return filtered
+	}
+
+	for _, err := range a.Errors() {
+		if pred(err) {
+			filtered.errs = append(filtered.errs, err)
+		}
+	}
+
+	return filtered
+}
+
+// FilterSeverity returns a new aggregate with the errors whose severity
+// level is at least min_level.
+//
+// Parameters:
+//   - min_level: The minimum severity level.
+//
+// Returns:
+//   - *Aggregate: A pointer to the new Aggregate. Never returns nil.
+func (a *Aggregate) FilterSeverity(min_level SeverityLevel) *Aggregate {
+	return a.Filter(func(err error) bool {
+		return severity_of(err) >= min_level
+	})
+}
+
+// FilterCode returns a new aggregate with the errors of the aggregate that
+// have the given code (see Is).
+//
+// Parameters:
+//   - a: The aggregate to filter.
+//   - code: The error code to filter by.
+//
+// Returns:
+//   - *Aggregate: A pointer to the new Aggregate. Never returns nil.
+func FilterCode[T ErrorCoder](a *Aggregate, code T) *Aggregate {
+	return a.Filter(func(err error) bool {
+		return Is(err, code)
+	})
+}
@@ -0,0 +1,174 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// disable_stack_capture disables the capture of stack traces for the
+// duration of the test so that the output is stable.
+func disable_stack_capture(t *testing.T) {
+	t.Helper()
+
+	enabled := StackCaptureEnabled()
+	SetStackCapture(false)
+
+	t.Cleanup(func() {
+		SetStackCapture(enabled)
+	})
+}
+
+func TestAggregateConcurrentAdd(t *testing.T) {
+	const (
+		workers = 8
+		per     = 100
+	)
+
+	agg := NewAggregate()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < per; j++ {
+				agg.Add(New(OperationFail, "fail"))
+
+				_ = agg.Len()
+				_ = agg.Error()
+				_, _ = agg.Severity()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := agg.Len(); got != workers*per {
+		t.Errorf("Len() = %d, want %d", got, workers*per)
+	}
+}
+
+func TestAggregateAddIgnoresNil(t *testing.T) {
+	var typed_nil *Err
+
+	agg := NewAggregate(nil, typed_nil)
+	agg.Add(nil)
+
+	if agg.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", agg.Len())
+	}
+
+	if agg.ErrorOrNil() != nil {
+		t.Errorf("ErrorOrNil() = %v, want nil", agg.ErrorOrNil())
+	}
+}
+
+func TestAggregateSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		errs    []error
+		want    SeverityLevel
+		want_ok bool
+	}{
+		{"empty", nil, INFO, false},
+		{"single", []error{NewWithSeverity(WARNING, BadParameter, "w")}, WARNING, true},
+		{"highest wins", []error{NewWithSeverity(INFO, BadParameter, "i"), NewWithSeverity(FATAL, BadParameter, "f"), NewWithSeverity(WARNING, BadParameter, "w")}, FATAL, true},
+		{"foreign is ERROR", []error{NewWithSeverity(WARNING, BadParameter, "w"), fmt.Errorf("plain")}, ERROR, true},
+		{"nested aggregate", []error{NewAggregate(NewWithSeverity(FATAL, BadParameter, "f")), NewWithSeverity(INFO, BadParameter, "i")}, FATAL, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewAggregate(tt.errs...).Severity()
+			if got != tt.want || ok != tt.want_ok {
+				t.Errorf("Severity() = (%v, %t), want (%v, %t)", got, ok, tt.want, tt.want_ok)
+			}
+		})
+	}
+}
+
+func TestAggregateFilterSeverity(t *testing.T) {
+	info := NewWithSeverity(INFO, BadParameter, "i")
+	warning := NewWithSeverity(WARNING, NoSuchKey, "w")
+	fatal := NewWithSeverity(FATAL, OperationFail, "f")
+	plain := fmt.Errorf("plain")
+
+	agg := NewAggregate(info, warning, fatal, plain)
+
+	got := agg.FilterSeverity(WARNING).Errors()
+	want := []error{warning, fatal, plain}
+
+	if len(got) != len(want) {
+		t.Fatalf("FilterSeverity(WARNING) = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FilterSeverity(WARNING)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if n := agg.FilterSeverity(FATAL).Len(); n != 1 {
+		t.Errorf("FilterSeverity(FATAL).Len() = %d, want 1", n)
+	}
+
+	if agg.Len() != 4 {
+		t.Errorf("FilterSeverity modified the receiver")
+	}
+}
+
+func TestAggregateFilterCode(t *testing.T) {
+	bad1 := New(BadParameter, "b1")
+	bad2 := fmt.Errorf("wrapped: %w", New(BadParameter, "b2"))
+	missing := New(NoSuchKey, "k")
+
+	agg := NewAggregate(bad1, missing, bad2, fmt.Errorf("plain"))
+
+	got := FilterCode(agg, BadParameter).Errors()
+	if len(got) != 2 || got[0] != bad1 || got[1] != bad2 {
+		t.Errorf("FilterCode(BadParameter) = %v, want [%v %v]", got, bad1, bad2)
+	}
+
+	if n := FilterCode(agg, InvalidUsage).Len(); n != 0 {
+		t.Errorf("FilterCode(InvalidUsage).Len() = %d, want 0", n)
+	}
+}
+
+func TestDisplayAggregate(t *testing.T) {
+	disable_stack_capture(t)
+
+	agg := NewAggregate(
+		NewWithSeverity(WARNING, NoSuchKey, "w1"),
+		New(BadParameter, "e1"),
+		fmt.Errorf("plain"),
+		NewWithSeverity(FATAL, OperationFail, "f1"),
+	)
+
+	var buf bytes.Buffer
+
+	err := DisplayError(&buf, agg)
+	if err != nil {
+		t.Fatalf("DisplayError() = %v", err)
+	}
+
+	want := "4 error(s) occurred\n" +
+		"\n" +
+		"FATAL (1):\n" +
+		"[FATAL] OperationFail: f1\n" +
+		"\n" +
+		"ERROR (2):\n" +
+		"[ERROR] BadParameter: e1\n" +
+		"plain\n" +
+		"\n" +
+		"WARNING (1):\n" +
+		"[WARNING] NoSuchKey: w1\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("DisplayError() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// other_code is a code of a type other than errors.ErrorCode that shares
+// its integer values.
+type other_code int
+
+func (c other_code) Int() int       { return int(c) }
+func (c other_code) String() string { return "other_code" }
+
+func TestExitCodeOf(t *testing.T) {
+	custom := NewExitTable()
+	custom.Set(other_code(errors.NoSuchKey), ExitNoInput)
+	custom.SetSeverity(errors.FATAL, ExitConfig)
+	custom.SetFallback(ExitSoftware)
+
+	tests := []struct {
+		name  string
+		table *ExitTable
+		err   error
+		want  int
+	}{
+		{"nil", nil, nil, ExitOK},
+		{"code", nil, errors.New(errors.BadParameter, "bad"), ExitUsage},
+		{"wrapped code", nil, fmt.Errorf("wrap: %w", errors.New(errors.NoSuchKey, "missing")), ExitDataErr},
+		{"warning severity", nil, errors.NewWithSeverity(errors.WARNING, errors.OperationFail, "warn"), ExitOK},
+		{"info severity", nil, errors.NewWithSeverity(errors.INFO, errors.BadParameter, "info"), ExitOK},
+		{"unmapped severity", nil, errors.NewWithSeverity(errors.FATAL, errors.Canceled, "canceled"), ExitInterrupted},
+		{"unmapped code", nil, errors.New(other_code(7), "other"), ExitFailure},
+		{"foreign", nil, fmt.Errorf("plain"), ExitFailure},
+		{"panic", nil, errors.FromPanic("boom"), ExitSoftware},
+		{"severity over code", custom, errors.NewWithSeverity(errors.FATAL, errors.BadParameter, "bad"), ExitConfig},
+		{"code type", custom, errors.New(other_code(errors.NoSuchKey), "other"), ExitNoInput},
+		{"custom fallback", custom, fmt.Errorf("plain"), ExitSoftware},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.table
+			if table == nil {
+				table = NewExitTable()
+			}
+
+			if got := table.ExitCodeOf(tt.err); got != tt.want {
+				t.Errorf("ExitCodeOf() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeOfNilTable(t *testing.T) {
+	var table *ExitTable
+
+	if got := table.ExitCodeOf(errors.New(errors.BadParameter, "bad")); got != ExitFailure {
+		t.Errorf("ExitCodeOf() = %d, want %d", got, ExitFailure)
+	}
+
+	if got := table.ExitCodeOf(nil); got != ExitOK {
+		t.Errorf("ExitCodeOf(nil) = %d, want %d", got, ExitOK)
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func() error
+		want      int
+		want_out  string
+		want_none []string
+	}{
+		{
+			name: "success",
+			fn:   func() error { return nil },
+			want: ExitOK,
+		},
+		{
+			name:      "error",
+			fn:        func() error { return errors.New(errors.BadParameter, "bad flag") },
+			want:      ExitUsage,
+			want_out:  "[ERROR] BadParameter: bad flag",
+			want_none: []string{"Stack trace:"},
+		},
+		{
+			name:      "panic",
+			fn:        func() error { panic("boom") },
+			want:      ExitSoftware,
+			want_out:  "[FATAL] OperationFail: panic: boom",
+			want_none: []string{"Stack trace:"},
+		},
+		{
+			name:     "panic with error",
+			fn:       func() error { panic(errors.New(errors.NoSuchKey, "missing")) },
+			want:     ExitDataErr,
+			want_out: "[FATAL] NoSuchKey: missing",
+		},
+		{
+			name:     "nil fn",
+			fn:       nil,
+			want:     ExitUsage,
+			want_out: `parameter ("fn") must not be nil`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			r := Runner{Stderr: &buf}
+
+			if got := r.Run(tt.fn); got != tt.want {
+				t.Errorf("Run() = %d, want %d", got, tt.want)
+			}
+
+			out := buf.String()
+
+			if tt.want_out == "" && out != "" {
+				t.Errorf("output = %q, want none", out)
+			}
+
+			if !strings.Contains(out, tt.want_out) {
+				t.Errorf("output = %q, want it to contain %q", out, tt.want_out)
+			}
+
+			for _, s := range tt.want_none {
+				if strings.Contains(out, s) {
+					t.Errorf("output = %q, want it not to contain %q", out, s)
+				}
+			}
+		})
+	}
+}
+
+func TestRunnerMain(t *testing.T) {
+	var buf bytes.Buffer
+
+	var got []int
+
+	r := Runner{
+		Stderr: &buf,
+		Exit:   func(code int) { got = append(got, code) },
+	}
+
+	r.Main(func() error { return errors.New(errors.DeadlineExceeded, "too slow") })
+
+	if len(got) != 1 || got[0] != ExitTempFail {
+		t.Errorf("exit codes = %v, want [%d]", got, ExitTempFail)
+	}
+}
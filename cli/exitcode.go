@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"sync"
+
+	errors "github.com/PlayerR9/go-errors"
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// Exit codes, as defined by sysexits.h where applicable.
+const (
+	// ExitOK means that the program succeeded.
+	ExitOK int = 0
+
+	// ExitFailure is the generic failure exit code.
+	ExitFailure int = 1
+
+	// ExitUsage means that the command was used incorrectly (EX_USAGE).
+	ExitUsage int = 64
+
+	// ExitDataErr means that the input data was incorrect (EX_DATAERR).
+	ExitDataErr int = 65
+
+	// ExitNoInput means that an input file did not exist or was not
+	// readable (EX_NOINPUT).
+	ExitNoInput int = 66
+
+	// ExitSoftware means that an internal software error was detected
+	// (EX_SOFTWARE).
+	ExitSoftware int = 70
+
+	// ExitTempFail means that a temporary failure occurred and the user is
+	// invited to retry (EX_TEMPFAIL).
+	ExitTempFail int = 75
+
+	// ExitNoPerm means that the user did not have sufficient permission
+	// (EX_NOPERM).
+	ExitNoPerm int = 77
+
+	// ExitConfig means that a configuration error occurred (EX_CONFIG).
+	ExitConfig int = 78
+
+	// ExitInterrupted is the exit code of a program interrupted by SIGINT.
+	ExitInterrupted int = 130
+)
+
+// default_table is the exit table used when none is provided.
+var default_table *ExitTable = NewExitTable()
+
+// ExitTable maps error codes and severity levels to process exit codes.
+// It is safe for concurrent use.
+type ExitTable struct {
+	// mu protects the table.
+	mu sync.RWMutex
+
+	// codes is the mapping of codes to exit codes.
+	codes internal.CodeMap[int]
+
+	// severities is the mapping of severity levels to exit codes.
+	severities map[errors.SeverityLevel]int
+
+	// fallback is the exit code used for unmapped codes.
+	fallback int
+}
+
+// NewExitTable creates a new ExitTable with the default mapping.
+//
+// Returns:
+//   - *ExitTable: A pointer to the new ExitTable. Never returns nil.
+//
+// Default mapping:
+//   - errors.INFO: 0
+//   - errors.WARNING: 0
+//   - errors.BadParameter: 64 (EX_USAGE)
+//   - errors.InvalidUsage: 64 (EX_USAGE)
+//   - errors.NoSuchKey: 65 (EX_DATAERR)
+//   - errors.OperationFail: 70 (EX_SOFTWARE)
+//   - errors.Canceled: 130
+//   - errors.DeadlineExceeded: 75 (EX_TEMPFAIL)
+//   - Any other code: 1
+func NewExitTable() *ExitTable {
+	et := &ExitTable{
+		severities: make(map[errors.SeverityLevel]int),
+		fallback:   ExitFailure,
+	}
+
+	et.SetSeverity(errors.INFO, ExitOK)
+	et.SetSeverity(errors.WARNING, ExitOK)
+
+	et.Set(errors.BadParameter, ExitUsage)
+	et.Set(errors.InvalidUsage, ExitUsage)
+	et.Set(errors.NoSuchKey, ExitDataErr)
+	et.Set(errors.OperationFail, ExitSoftware)
+	et.Set(errors.Canceled, ExitInterrupted)
+	et.Set(errors.DeadlineExceeded, ExitTempFail)
+
+	return et
+}
+
+// Set maps the code to the given exit code. Does nothing if the receiver
+// or the code is nil.
+//
+// Parameters:
+//   - code: The code to map.
+//   - exit: The exit code.
+func (et *ExitTable) Set(code errors.ErrorCoder, exit int) {
+	if et == nil || code == nil {
+		return
+	}
+
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	et.codes.Set(code, exit)
+}
+
+// SetSeverity maps the severity level to the given exit code. Does nothing
+// if the receiver is nil.
+//
+// Parameters:
+//   - level: The severity level to map.
+//   - exit: The exit code.
+//
+// Severity mappings take precedence over code mappings.
+func (et *ExitTable) SetSeverity(level errors.SeverityLevel, exit int) {
+	if et == nil {
+		return
+	}
+
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	if et.severities == nil {
+		et.severities = make(map[errors.SeverityLevel]int)
+	}
+
+	et.severities[level] = exit
+}
+
+// SetFallback sets the exit code used for codes that are not mapped. Does
+// nothing if the receiver is nil.
+//
+// Parameters:
+//   - exit: The exit code.
+func (et *ExitTable) SetFallback(exit int) {
+	if et == nil {
+		return
+	}
+
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	et.fallback = exit
+}
+
+// ExitCode returns the exit code of the given code.
+//
+// Parameters:
+//   - code: The code to look up.
+//
+// Returns:
+//   - int: The exit code. 1 if the receiver is nil.
+func (et *ExitTable) ExitCode(code errors.ErrorCoder) int {
+	if et == nil {
+		return ExitFailure
+	}
+
+	et.mu.RLock()
+	defer et.mu.RUnlock()
+
+	if code == nil {
+		return et.fallback
+	}
+
+	exit, ok := et.codes.Get(code)
+	if !ok {
+		return et.fallback
+	}
+
+	return exit
+}
+
+// ExitCodeOf returns the exit code of the given error.
+//
+// Parameters:
+//   - err: The error to look up.
+//
+// Returns:
+//   - int: The exit code of the outermost *errors.Err of the chain, or the
+//     fallback exit code if there is none. 0 if err is nil.
+//
+// The severity level of the error is looked up first and its code only if
+// the severity level is not mapped.
+func (et *ExitTable) ExitCodeOf(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	e, ok := errors.As(err)
+	if !ok {
+		return et.ExitCode(nil)
+	}
+
+	if et != nil {
+		et.mu.RLock()
+		exit, ok := et.severities[e.Severity]
+		et.mu.RUnlock()
+
+		if ok {
+			return exit
+		}
+	}
+
+	return et.ExitCode(e.Code)
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// Runner runs the main function of a command-line program, displays the
+// error it returns and exits with the matching exit code. The zero value
+// is ready to use.
+type Runner struct {
+	// Table maps errors to exit codes. If nil, the default mapping is used.
+	Table *ExitTable
+
+	// Renderer renders the error. If nil, errors.TextRenderer{} is used so
+	// that end users do not see stack traces.
+	Renderer errors.Renderer
+
+	// Stderr is the writer errors are rendered to. If nil, os.Stderr is
+	// used.
+	Stderr io.Writer
+
+	// Exit terminates the program. If nil, os.Exit is used.
+	Exit func(code int)
+}
+
+// Run calls fn and displays the error it returns, if any.
+//
+// Parameters:
+//   - fn: The main function of the program.
+//
+// Returns:
+//   - int: The exit code of the error (see ExitTable.ExitCodeOf). 0 if fn
+//     succeeded.
+//
+// Panics raised by fn are recovered and displayed as FATAL errors (see
+// errors.FromPanic).
+func (r *Runner) Run(fn func() error) (exit int) {
+	if r == nil {
+		r = &Runner{}
+	}
+
+	table := r.Table
+	if table == nil {
+		table = default_table
+	}
+
+	defer func() {
+		r_val := recover()
+		if r_val == nil {
+			return
+		}
+
+		err := errors.FromPanic(r_val)
+
+		r.display(err)
+
+		exit = table.ExitCodeOf(err)
+	}()
+
+	var err error
+
+	if fn == nil {
+		err = errors.NewErrNilParameter("Runner.Run()", "fn")
+	} else {
+		err = fn()
+	}
+
+	if err == nil {
+		return ExitOK
+	}
+
+	r.display(err)
+
+	return table.ExitCodeOf(err)
+}
+
+// display renders the error to the configured writer.
+//
+// Parameters:
+//   - err: The error to display. Assumed to be non-nil.
+func (r *Runner) display(err error) {
+	w := r.Stderr
+	if w == nil {
+		w = os.Stderr
+	}
+
+	renderer := r.Renderer
+	if renderer == nil {
+		renderer = errors.TextRenderer{}
+	}
+
+	_ = errors.DisplayErrorWith(w, err, renderer)
+}
+
+// Main is like Run but terminates the program with the exit code.
+//
+// Parameters:
+//   - fn: The main function of the program.
+func (r *Runner) Main(fn func() error) {
+	if r == nil {
+		r = &Runner{}
+	}
+
+	exit := r.Run(fn)
+
+	if r.Exit == nil {
+		os.Exit(exit)
+	}
+
+	r.Exit(exit)
+}
+
+// Main runs fn with the default settings and terminates the program with
+// the exit code of the error it returns.
+//
+// Parameters:
+//   - fn: The main function of the program.
+//
+// Example:
+//
+//	func main() {
+//		cli.Main(run)
+//	}
+func Main(fn func() error) {
+	var r Runner
+	r.Main(fn)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"regexp"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// Code is the definition of an error code in a catalog.
+type Code struct {
+	// Name is the name of the code. Must be a valid Go identifier.
+	Name string `json:"name"`
+
+	// Value is the integer value of the code.
+	Value int `json:"value"`
+
+	// Doc is the documentation of the code.
+	Doc string `json:"doc,omitempty"`
+
+	// Severity is the default severity level of the code. Defaults to ERROR.
+	Severity string `json:"severity,omitempty"`
+
+	// Message is the default message template of the code. Parameters are
+	// written as {name} or {name:q} (see errors.NewTemplate) and become
+	// arguments of the generated constructor.
+	Message string `json:"message,omitempty"`
+
+	// HTTPStatus is the HTTP status code of the code. 0 if unspecified.
+	// httperr.StatusTable uses it for the codes it has no entry for.
+	HTTPStatus int `json:"http_status,omitempty"`
+
+	// Suggestions are the suggestions added by the generated constructor.
+	Suggestions []string `json:"suggestions,omitempty"`
+
+	// level is the parsed severity level.
+	level errors.SeverityLevel
+
+	// params are the parameters of the message template, in order of first
+	// appearance.
+	params []string
+}
+
+// Catalog is a catalog of error codes.
+type Catalog struct {
+	// Package is the name of the package of the generated file.
+	Package string `json:"package"`
+
+	// Type is the name of the generated code type. Defaults to ErrorCode.
+	Type string `json:"type,omitempty"`
+
+	// Namespace is the namespace the code type is registered under. If empty,
+	// the code type is not registered.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Codes are the codes of the catalog.
+	Codes []*Code `json:"codes"`
+}
+
+// param_rx matches the parameters of a message template.
+var param_rx = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)(?::q)?\}`)
+
+// LoadCatalog loads and validates a catalog from a JSON file.
+//
+// Parameters:
+//   - path: The path of the catalog.
+//
+// Returns:
+//   - *Catalog: The catalog. Nil if an error occurred.
+//   - error: An error if the catalog cannot be read or is invalid.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Catalog
+
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog %q: %w", path, err)
+	}
+
+	err = c.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog %q: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// generated_names are the identifiers used in the body of the generated
+// constructors, which parameters must not shadow.
+var generated_names = map[string]struct{}{
+	"frame":   {},
+	"err":     {},
+	"errors":  {},
+	"strconv": {},
+}
+
+// is_reserved checks whether a template parameter would collide with an
+// identifier of the generated code.
+//
+// Parameters:
+//   - param: The name of the parameter.
+//   - names: The names of the codes of the catalog.
+//
+// Returns:
+//   - bool: True if the name is reserved, false otherwise.
+func (c *Catalog) is_reserved(param string, names map[string]struct{}) bool {
+	if _, ok := generated_names[param]; ok {
+		return true
+	} else if _, ok := names[param]; ok {
+		return true
+	}
+
+	return param == c.Type || token.IsKeyword(param) || types.Universe.Lookup(param) != nil
+}
+
+// validate validates the catalog and fills its defaults.
+//
+// Returns:
+//   - error: An error if the catalog is invalid.
+func (c *Catalog) validate() error {
+	if !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("package %q is not a valid identifier", c.Package)
+	}
+
+	if c.Type == "" {
+		c.Type = "ErrorCode"
+	} else if !token.IsIdentifier(c.Type) || !token.IsExported(c.Type) {
+		return fmt.Errorf("type %q is not a valid exported identifier", c.Type)
+	}
+
+	if len(c.Codes) == 0 {
+		return fmt.Errorf("catalog has no codes")
+	}
+
+	names := make(map[string]struct{}, len(c.Codes))
+	values := make(map[int]string, len(c.Codes))
+
+	for _, code := range c.Codes {
+		if code == nil {
+			return fmt.Errorf("catalog has a null code")
+		}
+
+		if !token.IsIdentifier(code.Name) || !token.IsExported(code.Name) {
+			return fmt.Errorf("code name %q is not a valid exported identifier", code.Name)
+		}
+
+		if _, ok := names[code.Name]; ok {
+			return fmt.Errorf("code %q is defined more than once", code.Name)
+		}
+
+		names[code.Name] = struct{}{}
+
+		if other, ok := values[code.Value]; ok {
+			return fmt.Errorf("codes %q and %q have the same value (%d)", other, code.Name, code.Value)
+		}
+
+		values[code.Value] = code.Name
+
+		if code.Severity == "" {
+			code.level = errors.ERROR
+		} else {
+			level, err := errors.ParseSeverityLevel(code.Severity)
+			if err != nil {
+				return fmt.Errorf("code %q has an invalid severity %q", code.Name, code.Severity)
+			}
+
+			code.level = level
+		}
+
+		if code.HTTPStatus != 0 && (code.HTTPStatus < 100 || code.HTTPStatus > 599) {
+			return fmt.Errorf("code %q has an invalid HTTP status (%d)", code.Name, code.HTTPStatus)
+		}
+	}
+
+	for _, code := range c.Codes {
+		seen := make(map[string]struct{})
+
+		for _, match := range param_rx.FindAllStringSubmatch(code.Message, -1) {
+			param := match[1]
+
+			if c.is_reserved(param, names) {
+				return fmt.Errorf("code %q has a reserved parameter name %q", code.Name, param)
+			}
+
+			if _, ok := seen[param]; !ok {
+				seen[param] = struct{}{}
+				code.params = append(code.params, param)
+			}
+		}
+	}
+
+	return nil
+}
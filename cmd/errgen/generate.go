@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// write_doc writes a doc comment with the given indentation.
+//
+// Parameters:
+//   - b: The builder to write into.
+//   - doc: The documentation.
+//   - indent: The indentation of the comment.
+func write_doc(b *strings.Builder, doc, indent string) {
+	for _, line := range strings.Split(strings.TrimSpace(doc), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			b.WriteString(indent + "//\n")
+		} else {
+			b.WriteString(indent + "// " + line + "\n")
+		}
+	}
+}
+
+// GenerateGo generates the Go source of the catalog.
+//
+// Returns:
+//   - []byte: The formatted Go source.
+//   - error: An error if the generated source cannot be formatted.
+func (c *Catalog) GenerateGo() ([]byte, error) {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by \"errgen\"; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", c.Package)
+	b.WriteString("import (\n\t\"strconv\"\n\n\terrors \"github.com/PlayerR9/go-errors\"\n)\n\n")
+
+	fmt.Fprintf(&b, "// %s is the type of the error codes of the package.\n", c.Type)
+	fmt.Fprintf(&b, "type %s int\n\n", c.Type)
+
+	b.WriteString("const (\n")
+
+	for i, code := range c.Codes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		if code.Doc != "" {
+			write_doc(&b, code.Doc, "\t")
+		}
+
+		fmt.Fprintf(&b, "\t%s %s = %d\n", code.Name, c.Type, code.Value)
+	}
+
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "// Int implements the errors.ErrorCoder interface.\n")
+	fmt.Fprintf(&b, "func (c %s) Int() int {\n\treturn int(c)\n}\n\n", c.Type)
+
+	fmt.Fprintf(&b, "// String implements the fmt.Stringer interface.\n")
+	fmt.Fprintf(&b, "func (c %s) String() string {\n\tswitch c {\n", c.Type)
+
+	for _, code := range c.Codes {
+		fmt.Fprintf(&b, "\tcase %s:\n\t\treturn %q\n", code.Name, code.Name)
+	}
+
+	fmt.Fprintf(&b, "\tdefault:\n\t\treturn \"%s(\" + strconv.Itoa(int(c)) + \")\"\n\t}\n}\n\n", c.Type)
+
+	if c.Namespace != "" {
+		fmt.Fprintf(&b, "// Namespace implements the errors.Namespacer interface.\n")
+		fmt.Fprintf(&b, "func (c %s) Namespace() string {\n\treturn %q\n}\n\n", c.Type, c.Namespace)
+	}
+
+	b.WriteString("// Severity returns the default severity level of the code.\n//\n")
+	b.WriteString("// Returns:\n//   - errors.SeverityLevel: The default severity level. errors.ERROR if\n//     the code is not defined.\n")
+	fmt.Fprintf(&b, "func (c %s) Severity() errors.SeverityLevel {\n\tswitch c {\n", c.Type)
+
+	for _, code := range c.Codes {
+		if code.level != errors.ERROR {
+			fmt.Fprintf(&b, "\tcase %s:\n\t\treturn errors.%s\n", code.Name, code.level)
+		}
+	}
+
+	b.WriteString("\tdefault:\n\t\treturn errors.ERROR\n\t}\n}\n\n")
+
+	b.WriteString("// HTTPStatus implements the httperr.HTTPStatuser interface.\n//\n")
+	b.WriteString("// Returns:\n//   - int: The HTTP status code. 0 if unspecified.\n")
+	fmt.Fprintf(&b, "func (c %s) HTTPStatus() int {\n\tswitch c {\n", c.Type)
+
+	for _, code := range c.Codes {
+		if code.HTTPStatus != 0 {
+			fmt.Fprintf(&b, "\tcase %s:\n\t\treturn %d\n", code.Name, code.HTTPStatus)
+		}
+	}
+
+	b.WriteString("\tdefault:\n\t\treturn 0\n\t}\n}\n")
+
+	for _, code := range c.Codes {
+		fmt.Fprintf(&b, "\n// NewErr%s creates a new errors.Err error with the code %s.\n//\n", code.Name, code.Name)
+		b.WriteString("// Parameters:\n//   - frame: The frame of the error.\n")
+
+		for _, param := range code.params {
+			fmt.Fprintf(&b, "//   - %s: The %s of the message.\n", param, param)
+		}
+
+		b.WriteString("//\n// Returns:\n//   - *errors.Err: The new error. Never returns nil.\n")
+
+		params := append([]string{"frame"}, code.params...)
+
+		fmt.Fprintf(&b, "func NewErr%s(%s string) *errors.Err {\n", code.Name, strings.Join(params, ", "))
+		if len(code.params) == 0 {
+			msg := code.Message
+			if msg == "" {
+				msg = code.Name
+			}
+
+			fmt.Fprintf(&b, "\terr := errors.NewWithSeverity(errors.%s, %s, %q)\n", code.level, code.Name, msg)
+		} else {
+			fmt.Fprintf(&b, "\terr := errors.NewTemplate(%s, %q, map[string]any{\n", code.Name, code.Message)
+
+			for _, param := range code.params {
+				fmt.Fprintf(&b, "\t\t%q: %s,\n", param, param)
+			}
+
+			b.WriteString("\t})\n")
+
+			if code.level != errors.ERROR {
+				fmt.Fprintf(&b, "\terr.ChangeSeverity(errors.%s)\n", code.level)
+			}
+		}
+
+		for _, suggestion := range code.Suggestions {
+			fmt.Fprintf(&b, "\terr.AddSuggestion(%q)\n", suggestion)
+		}
+
+		b.WriteString("\n\terr.AddFrame(frame)\n\n\treturn err\n}\n")
+	}
+
+	if c.Namespace != "" {
+		fmt.Fprintf(&b, "\nfunc init() {\n\terr := errors.RegisterCode[%s](%q)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n}\n", c.Type, c.Namespace)
+	}
+
+	return format.Source([]byte(b.String()))
+}
+
+// markdown_cell escapes the text for a Markdown table cell.
+//
+// Parameters:
+//   - text: The text to escape.
+//
+// Returns:
+//   - string: The escaped text.
+func markdown_cell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// GenerateMarkdown generates the Markdown reference of the catalog.
+//
+// Returns:
+//   - []byte: The Markdown document.
+func (c *Catalog) GenerateMarkdown() []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s.%s reference\n\n", c.Package, c.Type)
+	b.WriteString("<!-- Code generated by \"errgen\"; DO NOT EDIT. -->\n\n")
+
+	if c.Namespace != "" {
+		fmt.Fprintf(&b, "Namespace: `%s`\n\n", c.Namespace)
+	}
+
+	b.WriteString("| Code | Value | Severity | HTTP status | Message |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, code := range c.Codes {
+		status := "-"
+		if code.HTTPStatus != 0 {
+			status = strconv.Itoa(code.HTTPStatus)
+		}
+
+		fmt.Fprintf(&b, "| [%s](#%s) | %d | %s | %s | %s |\n", code.Name, strings.ToLower(code.Name), code.Value, code.level, status, markdown_cell(code.Message))
+	}
+
+	for _, code := range c.Codes {
+		fmt.Fprintf(&b, "\n## %s\n\n", code.Name)
+
+		if code.Doc != "" {
+			b.WriteString(strings.TrimSpace(code.Doc) + "\n\n")
+		}
+
+		params := append([]string{"frame"}, code.params...)
+
+		fmt.Fprintf(&b, "Constructor: `NewErr%s(%s string)`\n", code.Name, strings.Join(params, ", "))
+
+		if len(code.Suggestions) > 0 {
+			b.WriteString("\nSuggestions:\n\n")
+
+			for _, suggestion := range code.Suggestions {
+				fmt.Fprintf(&b, "- %s\n", suggestion)
+			}
+		}
+	}
+
+	return []byte(b.String())
+}
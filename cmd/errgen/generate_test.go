@@ -0,0 +1,253 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// new_catalog creates a catalog with a single code whose message is the
+// given template.
+func new_catalog(message string) *Catalog {
+	return &Catalog{
+		Package:   "gentest",
+		Type:      "Code",
+		Namespace: "gentest",
+		Codes: []*Code{
+			{Name: "NotFound", Value: 1, Message: message},
+			{Name: "Broken", Value: 2},
+		},
+	}
+}
+
+func TestValidateReservedParams(t *testing.T) {
+	reserved := []string{
+		"frame", "err", "errors", "strconv",
+		"Code", "NotFound", "Broken",
+		"string", "any", "nil", "len",
+		"func", "type", "map",
+	}
+
+	for _, name := range reserved {
+		t.Run(name, func(t *testing.T) {
+			err := new_catalog("bad {" + name + "}").validate()
+			if err == nil {
+				t.Fatalf("validate() accepted the parameter %q", name)
+			}
+
+			if !strings.Contains(err.Error(), "reserved parameter name") {
+				t.Errorf("validate() = %v, want a reserved parameter error", err)
+			}
+		})
+	}
+
+	err := new_catalog("key {key:q} in {table}").validate()
+	if err != nil {
+		t.Errorf("validate() = %v, want nil", err)
+	}
+}
+
+// new_full_catalog creates a validated catalog that uses every feature of
+// the generator.
+func new_full_catalog(t *testing.T) *Catalog {
+	t.Helper()
+
+	c := &Catalog{
+		Package:   "gentest",
+		Type:      "Code",
+		Namespace: "gentest",
+		Codes: []*Code{
+			{
+				Name:        "NotFound",
+				Value:       1,
+				Doc:         "NotFound occurs when a key is missing.",
+				Severity:    "WARNING",
+				Message:     "key {key:q} not found in {table} ({key})",
+				HTTPStatus:  404,
+				Suggestions: []string{"Check the key"},
+			},
+			{
+				Name:    "Broken",
+				Value:   2,
+				Message: "something | is broken",
+			},
+			{
+				Name:  "Silent",
+				Value: 3,
+			},
+		},
+	}
+
+	err := c.validate()
+	if err != nil {
+		t.Fatalf("validate() = %v", err)
+	}
+
+	return c
+}
+
+// generated_test_src is a test of the code generated from new_full_catalog,
+// run inside the generated package.
+const generated_test_src string = `package gentest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+	"github.com/PlayerR9/go-errors/httperr"
+)
+
+func TestGenerated(t *testing.T) {
+	st := httperr.NewStatusTable()
+
+	tests := []struct {
+		name        string
+		err         *errors.Err
+		code        Code
+		msg         string
+		severity    errors.SeverityLevel
+		status      int
+		suggestions []string
+	}{
+		{"NotFound", NewErrNotFound("Find()", "k", "users"), NotFound, "[WARNING] gentest.NotFound: key \"k\" not found in users (k)", errors.WARNING, http.StatusNotFound, []string{"Check the key"}},
+		{"Broken", NewErrBroken("Find()"), Broken, "[ERROR] gentest.Broken: something | is broken", errors.ERROR, http.StatusInternalServerError, nil},
+		{"Silent", NewErrSilent("Find()"), Silent, "[ERROR] gentest.Silent: Silent", errors.ERROR, http.StatusInternalServerError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.msg {
+				t.Errorf("Error() = %q, want %q", got, tt.msg)
+			}
+
+			if !errors.Is(tt.err, tt.code) {
+				t.Errorf("errors.Is() = false, want true")
+			}
+
+			if tt.err.Severity != tt.severity || tt.code.Severity() != tt.severity {
+				t.Errorf("severities = (%v, %v), want %v", tt.err.Severity, tt.code.Severity(), tt.severity)
+			}
+
+			if got := st.StatusOf(tt.err); got != tt.status {
+				t.Errorf("StatusOf() = %d, want %d", got, tt.status)
+			}
+
+			if got := tt.err.Suggestions(); !reflect.DeepEqual(got, tt.suggestions) {
+				t.Errorf("Suggestions() = %q, want %q", got, tt.suggestions)
+			}
+
+			if got := tt.err.Info.Frames; !reflect.DeepEqual(got, []string{"Find()"}) {
+				t.Errorf("Frames = %q, want [Find()]", got)
+			}
+
+			code, ok := errors.LookupCode("gentest", tt.code.Int())
+			if !ok || code != tt.code {
+				t.Errorf("LookupCode() = (%v, %t), want (%v, true)", code, ok, tt.code)
+			}
+		})
+	}
+
+	if got := Code(9).String(); got != "Code(9)" {
+		t.Errorf("String() = %q, want %q", got, "Code(9)")
+	}
+
+	if got := Code(9).HTTPStatus(); got != 0 {
+		t.Errorf("HTTPStatus() = %d, want 0", got)
+	}
+}
+`
+
+func TestGenerateGo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of the generated code in short mode")
+	}
+
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := new_full_catalog(t)
+
+	src, err := c.GenerateGo()
+	if err != nil {
+		t.Fatalf("GenerateGo() = %v", err)
+	}
+
+	dir := t.TempDir()
+
+	go_mod := "module gentest\n\ngo 1.23.1\n\n" +
+		"require github.com/PlayerR9/go-errors v0.0.0\n\n" +
+		"replace github.com/PlayerR9/go-errors => " + root + "\n"
+
+	files := map[string]string{
+		"go.mod":            go_mod,
+		"codes_gen.go":      string(src),
+		"codes_gen_test.go": generated_test_src,
+	}
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, args := range [][]string{{"vet", "./..."}, {"test", "./..."}} {
+		cmd := exec.Command(gobin, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("go %s failed on the generated code: %v\n%s\n\n%s", args[0], err, out, src)
+		}
+	}
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	got := string(new_full_catalog(t).GenerateMarkdown())
+
+	want := "# gentest.Code reference\n" +
+		"\n" +
+		"<!-- Code generated by \"errgen\"; DO NOT EDIT. -->\n" +
+		"\n" +
+		"Namespace: `gentest`\n" +
+		"\n" +
+		"| Code | Value | Severity | HTTP status | Message |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| [NotFound](#notfound) | 1 | WARNING | 404 | key {key:q} not found in {table} ({key}) |\n" +
+		"| [Broken](#broken) | 2 | ERROR | - | something \\| is broken |\n" +
+		"| [Silent](#silent) | 3 | ERROR | - |  |\n" +
+		"\n" +
+		"## NotFound\n" +
+		"\n" +
+		"NotFound occurs when a key is missing.\n" +
+		"\n" +
+		"Constructor: `NewErrNotFound(frame, key, table string)`\n" +
+		"\n" +
+		"Suggestions:\n" +
+		"\n" +
+		"- Check the key\n" +
+		"\n" +
+		"## Broken\n" +
+		"\n" +
+		"Constructor: `NewErrBroken(frame string)`\n" +
+		"\n" +
+		"## Silent\n" +
+		"\n" +
+		"Constructor: `NewErrSilent(frame string)`\n"
+
+	if got != want {
+		t.Errorf("GenerateMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+// Command errgen generates error code enums, their methods and constructors
+// from a JSON catalog.
+//
+// Usage:
+//
+//	errgen -catalog codes.json [-out codes_gen.go] [-doc CODES.md]
+//
+// It is meant to be used with go:generate:
+//
+//	//go:generate go run github.com/PlayerR9/go-errors/cmd/errgen -catalog codes.json
+//
+// Catalog format:
+//
+//	{
+//	  "package": "storage",
+//	  "type": "ErrorCode",
+//	  "namespace": "storage",
+//	  "codes": [
+//	    {
+//	      "name": "NoSuchKey",
+//	      "value": 0,
+//	      "doc": "NoSuchKey occurs when a key does not exist.",
+//	      "severity": "ERROR",
+//	      "message": "key {key} does not exist",
+//	      "http_status": 404,
+//	      "suggestions": ["Check the spelling of the key"]
+//	    }
+//	  ]
+//	}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// catalog_flag is the path of the catalog.
+	catalog_flag = flag.String("catalog", "", "path of the JSON catalog (required)")
+
+	// out_flag is the path of the generated Go file.
+	out_flag = flag.String("out", "", "path of the generated Go file (default: <type>_gen.go next to the catalog)")
+
+	// doc_flag is the path of the generated Markdown reference.
+	doc_flag = flag.String("doc", "", "path of the generated Markdown reference (default: none)")
+)
+
+func main() {
+	flag.Parse()
+
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "errgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the generator.
+//
+// Returns:
+//   - error: An error if the generation failed.
+func run() error {
+	if *catalog_flag == "" {
+		flag.Usage()
+		return fmt.Errorf("missing -catalog flag")
+	}
+
+	c, err := LoadCatalog(*catalog_flag)
+	if err != nil {
+		return err
+	}
+
+	out := *out_flag
+	if out == "" {
+		out = filepath.Join(filepath.Dir(*catalog_flag), strings.ToLower(c.Type)+"_gen.go")
+	}
+
+	src, err := c.GenerateGo()
+	if err != nil {
+		return fmt.Errorf("could not format generated code: %w", err)
+	}
+
+	err = os.WriteFile(out, src, 0644)
+	if err != nil {
+		return err
+	}
+
+	if *doc_flag == "" {
+		return nil
+	}
+
+	return os.WriteFile(*doc_flag, c.GenerateMarkdown(), 0644)
+}
@@ -3,6 +3,9 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/PlayerR9/go-errors/internal"
 )
@@ -20,15 +23,11 @@ func Is[T ErrorCoder](err error, code T) bool {
 		return false
 	}
 
-	var sub_err *Err
-
-	ok := errors.As(err, &sub_err)
-	if !ok {
-		return false
-	}
+	sub_err := find_err(err, func(e *Err) bool {
+		return has_code(e, code)
+	})
 
-	other, ok := sub_err.Code.(T)
-	return ok && other.Int() == code.Int()
+	return sub_err != nil
 }
 
 // As returns the error if it is of type T.
@@ -69,22 +68,65 @@ func AsWithCode[T ErrorCoder](err error, code T) (*Err, bool) {
 		return nil, false
 	}
 
-	var sub_err *Err
+	sub_err := find_err(err, func(e *Err) bool {
+		return has_code(e, code)
+	})
 
-	ok := errors.As(err, &sub_err)
-	if !ok {
-		return nil, false
-	}
+	return sub_err, sub_err != nil
+}
 
-	other, ok := sub_err.Code.(T)
-	if !ok || other.Int() != code.Int() {
-		return nil, false
+// has_code checks whether the error has the given code.
+//
+// Parameters:
+//   - e: The error to check. Assumed to be non-nil.
+//   - code: The error code to check.
+//
+// Returns:
+//   - bool: true if the code of the error is of type T and has the same
+//     integer value as code, false otherwise.
+func has_code[T ErrorCoder](e *Err, code T) bool {
+	other, ok := e.Code.(T)
+	return ok && other.Int() == code.Int()
+}
+
+// find_err walks the error chain, in depth-first order, and returns the
+// first *Err that satisfies the predicate.
+//
+// Parameters:
+//   - err: The error chain to walk.
+//   - pred: The predicate to satisfy. Assumed to be non-nil.
+//
+// Returns:
+//   - *Err: The first *Err that satisfies the predicate. Nil if none does.
+//
+// Both Unwrap() error and Unwrap() []error are followed.
+func find_err(err error, pred func(e *Err) bool) *Err {
+	for err != nil {
+		e, ok := err.(*Err)
+		if ok && e != nil && pred(e) {
+			return e
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, sub := range x.Unwrap() {
+				found := find_err(sub, pred)
+				if found != nil {
+					return found
+				}
+			}
+
+			return nil
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		default:
+			return nil
+		}
 	}
 
-	return sub_err, true
+	return nil
 }
 
-/*
 // Value is a function that returns the value of the context with the given key.
 //
 // Parameters:
@@ -94,81 +136,249 @@ func AsWithCode[T ErrorCoder](err error, code T) (*Err, bool) {
 // Returns:
 //   - T: The value of the context with the given key.
 //   - error: The error that occurred while getting the value.
-func Value[C ErrorCoder, T any](e *Err, key string) (T, error) {
-	// zero := *new(T)
-
-	// if e == nil || len(e.Context) == 0 {
-	// 	return zero, NewErrNoSuchKey("Value()", key)
-	// }
+//
+// Errors:
+//   - *Err with code NoSuchKey: If the key does not exist, has a nil value,
+//     or has a value that is not of type T.
+func Value[T any](e *Err, key string) (T, error) {
+	zero := *new(T)
 
-	// x, ok := e.Context[key]
-	// if !ok {
-	// 	return zero, NewErrNoSuchKey("Value()", key)
-	// }
+	x, ok := e.Value(key)
+	if !ok {
+		return zero, NewErrNoSuchKey("Value()", key)
+	}
 
-	// if x == nil {
-	// 	err := NewErrNoSuchKey("Value()", key)
-	// 	err.AddSuggestion("Found a key with the same name but has a nil value")
+	if x == nil {
+		err := NewErrNoSuchKey("Value()", key)
+		err.AddSuggestion("Found a key with the same name but has a nil value")
 
-	// 	return zero, err
-	// }
+		return zero, err
+	}
 
-	// val, ok := x.(T)
-	// if !ok {
-	// 	err := NewErrNoSuchKey("Value()", key)
-	// 	err.AddSuggestion(fmt.Sprintf("Found a key with the same name but has a value of type %T", x))
+	val, ok := x.(T)
+	if !ok {
+		err := NewErrNoSuchKey("Value()", key)
+		err.AddSuggestion(fmt.Sprintf("Found a key with the same name but has a value of type %T", x))
 
-	// 	return zero, err
-	// }
+		return zero, err
+	}
 
-	// return val, nil
-} */
+	return val, nil
+}
 
-/*
-// LimitErrorMsg is a function that limits the number of errors in an error chain.
+// LimitErrorMsg returns a truncated view of an error chain.
 //
 // Parameters:
 //   - err: The error to limit.
-//   - limit: The maximum number of errors to limit.
+//   - max_depth: The maximum number of errors of the chain to keep, err
+//     included. If less than or equal to 0, the depth is not limited.
+//   - max_bytes: The maximum number of bytes of each message. If less than
+//     or equal to 0, the messages are not limited.
 //
 // Returns:
-//   - error: The limited error.
+//   - error: The limited error. Nil if err is nil.
 //
-// If the error is nil or the limit is less than or equal to 0, the function returns nil.
-func LimitErrorMsg(err error, limit int) error {
-	if err == nil || limit <= 0 {
+// The original chain is never modified: every *Err of the view is a copy.
+// Causes beyond max_depth are replaced by a single marker error whose message
+// is "...N more cause(s)" and messages longer than max_bytes are cut and end
+// with "...". Errors that join several errors do not count towards the depth.
+func LimitErrorMsg(err error, max_depth, max_bytes int) error {
+	if err == nil {
 		return nil
 	}
 
-	target := err
+	return limit_err(err, max_depth, max_bytes, 1)
+}
 
-	for i := 0; i < limit; i++ {
-		w, ok := target.(Unwrapper)
-		if !ok {
-			return err
+// truncated_err marks the place where an error chain was truncated.
+type truncated_err struct {
+	// count is the number of errors that were removed.
+	count int
+}
+
+// Error implements the error interface.
+func (e *truncated_err) Error() string {
+	return "..." + strconv.Itoa(e.count) + " more cause(s)"
+}
+
+// limited_err is the view of a foreign error that wraps another error.
+type limited_err struct {
+	// message is the, possibly truncated, message of the error.
+	message string
+
+	// inner is the limited inner error.
+	inner error
+}
+
+// Error implements the error interface.
+func (e *limited_err) Error() string {
+	return e.message
+}
+
+// Unwrap returns the limited inner error.
+func (e *limited_err) Unwrap() error {
+	return e.inner
+}
+
+// limited_join is the view of an error that wraps several errors.
+type limited_join struct {
+	// inners are the limited inner errors.
+	inners []error
+}
+
+// Error implements the error interface.
+func (e *limited_join) Error() string {
+	msgs := make([]string, 0, len(e.inners))
+
+	for _, inner := range e.inners {
+		msgs = append(msgs, inner.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the limited inner errors.
+func (e *limited_join) Unwrap() []error {
+	return e.inners
+}
+
+// limit_msg cuts the message to at most max_bytes bytes, without splitting
+// a UTF-8 sequence.
+//
+// Parameters:
+//   - msg: The message to cut.
+//   - max_bytes: The maximum number of bytes. If less than or equal to 0,
+//     the message is returned as-is.
+//
+// Returns:
+//   - string: The, possibly cut, message.
+func limit_msg(msg string, max_bytes int) string {
+	if max_bytes <= 0 || len(msg) <= max_bytes {
+		return msg
+	}
+
+	end := max_bytes
+
+	for end > 0 && !utf8.RuneStart(msg[end]) {
+		end--
+	}
+
+	return msg[:end] + "..."
+}
+
+// count_errors counts the errors of a chain.
+//
+// Parameters:
+//   - err: The chain to count.
+//
+// Returns:
+//   - int: The number of errors. Errors that join several errors are not
+//     counted themselves.
+func count_errors(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		var count int
+
+		for _, sub := range x.Unwrap() {
+			count += count_errors(sub)
 		}
 
-		reason := w.Unwrap()
-		if reason == nil {
-			return err
+		return count
+	case interface{ Unwrap() error }:
+		return 1 + count_errors(x.Unwrap())
+	default:
+		return 1
+	}
+}
+
+// limit_err is the recursive helper of LimitErrorMsg.
+//
+// Parameters:
+//   - err: The error to limit.
+//   - max_depth: The maximum depth.
+//   - max_bytes: The maximum number of bytes of each message.
+//   - depth: The depth of err, starting at 1.
+//
+// Returns:
+//   - error: The limited error. Nil if err is nil.
+func limit_err(err error, max_depth, max_bytes, depth int) error {
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := err.(*Err); ok {
+		if e == nil {
+			return nil
 		}
 
-		target = reason
+		if max_depth > 0 && depth > max_depth {
+			return &truncated_err{count: count_errors(err)}
+		}
+
+		view := &Err{
+			Severity: e.Severity,
+			Code:     e.Code,
+			Message:  limit_msg(e.RenderMessage(), max_bytes),
+			Info:     e.Info.Copy(),
+		}
+
+		view.Info.Template = ""
+		view.Info.TemplateArgs = nil
+
+		view.Info.Inner = limit_err(view.Info.Inner, max_depth, max_bytes, depth+1)
+
+		return view
 	}
 
-	if target == nil {
-		return err
+	switch x := err.(type) {
+	case *Aggregate:
+		view := &Aggregate{}
+
+		for _, sub := range x.Errors() {
+			view.Add(limit_err(sub, max_depth, max_bytes, depth))
+		}
+
+		return view
+	case interface{ Unwrap() []error }:
+		var inners []error
+
+		for _, sub := range x.Unwrap() {
+			limited := limit_err(sub, max_depth, max_bytes, depth)
+			if limited != nil {
+				inners = append(inners, limited)
+			}
+		}
+
+		return &limited_join{
+			inners: inners,
+		}
 	}
 
-	w, ok := target.(Unwrapper)
-	if !ok {
-		return err
+	if max_depth > 0 && depth > max_depth {
+		return &truncated_err{count: count_errors(err)}
 	}
 
-	w.ChangeReason(nil)
+	x, ok := err.(interface{ Unwrap() error })
+	if !ok || x.Unwrap() == nil {
+		msg := limit_msg(err.Error(), max_bytes)
+		if msg == err.Error() {
+			return err
+		}
 
-	return err
-} */
+		return &limited_err{
+			message: msg,
+		}
+	}
+
+	return &limited_err{
+		message: limit_msg(err.Error(), max_bytes),
+		inner:   limit_err(x.Unwrap(), max_depth, max_bytes, depth+1),
+	}
+}
 
 // Merge merges the inner Info into the outer Info.
 //
@@ -179,78 +389,167 @@ func LimitErrorMsg(err error, limit int) error {
 // Returns:
 //   - *Info: A pointer to the new Info. Never returns nil.
 //
-// Note:
-//   - The other Info is the inner info of the current Info and, as such,
-//     when conflicts occur, the outer Info takes precedence.
+// Rules:
+//   - The outer template and its arguments are kept, as the message is the
+//     outer one. The inner template is never kept, even if outer is nil.
+//   - Suggestions are concatenated, the outer ones first.
+//   - Contexts are merged; the outer value wins on conflicting keys.
+//   - Frames are concatenated, the inner ones first, as they were added
+//     earlier.
+//   - Captured stack traces are concatenated, the inner one first, as it is
+//     closer to the origin of the error.
+//   - Spans are concatenated, the outer ones first.
+//   - The outer retry classification wins, unless unknown, and the longest
+//     retry delay is kept.
+//   - Inner errors are merged recursively with MergeErrors.
+//
+// Neither outer nor inner is modified.
 func Merge(outer, inner *internal.Info) *internal.Info {
 	if inner == nil {
 		return outer.Copy()
+	} else if outer == nil {
+		info := inner.Copy()
+
+		info.Template = ""
+		info.TemplateArgs = nil
+
+		return info
 	}
 
-	// suggestions := make([]string, 0, len(outer.Suggestions)+len(inner.Suggestions))
-	// suggestions = append(suggestions, outer.Suggestions...)
-	// suggestions = append(suggestions, inner.Suggestions...)
+	var template_args map[string]any
+
+	if len(outer.TemplateArgs) > 0 {
+		template_args = make(map[string]any, len(outer.TemplateArgs))
 
-	// context := make(map[string]any)
+		for key, value := range outer.TemplateArgs {
+			template_args[key] = value
+		}
+	}
 
-	// for key, value := range inner.Context {
-	// 	context[key] = value
-	// }
+	var suggestions []string
 
-	// for key, value := range outer.Context {
-	// 	context[key] = value
-	// }
+	suggestions = append(suggestions, outer.Suggestions...)
+	suggestions = append(suggestions, inner.Suggestions...)
 
-	// stack_trace := make([]string, 0, len(outer.StackTrace)+len(inner.StackTrace))
-	// stack_trace = append(stack_trace, outer.StackTrace...)
-	// stack_trace = append(stack_trace, inner.StackTrace...)
+	var context map[string]any
+
+	if len(outer.Context) > 0 || len(inner.Context) > 0 {
+		context = make(map[string]any, len(outer.Context)+len(inner.Context))
+	}
+
+	for key, value := range inner.Context {
+		context[key] = value
+	}
+
+	for key, value := range outer.Context {
+		context[key] = value
+	}
+
+	var frames []string
+
+	frames = append(frames, inner.Frames...)
+	frames = append(frames, outer.Frames...)
+
+	var callers []uintptr
+
+	callers = append(callers, inner.Callers...)
+	callers = append(callers, outer.Callers...)
+
+	retryability := outer.Retryability
+	if retryability == 0 {
+		retryability = inner.Retryability
+	}
+
+	var spans []internal.Span
+
+	spans = append(spans, outer.Spans...)
+	spans = append(spans, inner.Spans...)
 
 	return &internal.Info{
-		// Suggestions: suggestions,
+		Template:     outer.Template,
+		TemplateArgs: template_args,
+		Suggestions:  suggestions,
 		// Timestamp:   outer.Timestamp,
-		// Context:    context,
-		// StackTrace: stack_trace,
-		// Inner: MergeErrors(outer.Inner, inner.Inner),
+		Context: context,
+		Frames:  frames,
+		Callers: callers,
+		Spans:   spans,
+
+		Retryability: retryability,
+		RetryAfter:   max(outer.RetryAfter, inner.RetryAfter),
+
+		Inner: MergeErrors(outer.Inner, inner.Inner),
 	}
 }
 
+// MergeErrors merges the inner error into the outer error.
+//
+// Parameters:
+//   - outer: The outer error to merge.
+//   - inner: The inner error to merge.
+//
+// Returns:
+//   - error: The merged error. Nil if both errors are nil.
+//
+// Rules:
+//   - If either error is nil (including a nil *Err), the other is returned.
+//   - If neither is an *Err, they are wrapped as "outer: inner".
+//   - If both are *Err, the result has the severity, code and message of
+//     outer and their Info are merged with Merge.
+//   - If only outer is an *Err, the result is a copy of outer whose inner
+//     error is merged with inner.
+//   - If only inner is an *Err, the result is a copy of inner whose inner
+//     error is merged with outer, so that outer is reported as a cause.
+//
+// Neither outer nor inner is modified.
 func MergeErrors(outer, inner error) error {
-	if outer == nil {
-		return inner
-	} else if inner == nil {
-		return outer
+	o, ok1 := outer.(*Err)
+	if ok1 && o == nil {
+		outer = nil
 	}
 
-	o, ok1 := outer.(*Err)
 	i, ok2 := inner.(*Err)
+	if ok2 && i == nil {
+		inner = nil
+	}
 
-	if !ok1 && !ok2 {
-		return fmt.Errorf("%w: %w", outer, inner)
+	if outer == nil {
+		return inner
+	} else if inner == nil {
+		return outer
 	}
 
 	var err *Err
 
-	if ok1 {
+	switch {
+	case ok1 && ok2:
 		err = &Err{
 			Severity: o.Severity,
 			Code:     o.Code,
 			Message:  o.Message,
+			Info:     Merge(o.Info, i.Info),
 		}
-	} else {
+	case ok1:
+		err = &Err{
+			Severity: o.Severity,
+			Code:     o.Code,
+			Message:  o.Message,
+			Info:     o.Info.Copy(),
+		}
+
+		err.Info.Inner = MergeErrors(err.Info.Inner, inner)
+	case ok2:
 		err = &Err{
 			Severity: i.Severity,
 			Code:     i.Code,
 			Message:  i.Message,
+			Info:     i.Info.Copy(),
 		}
-	}
-
-	err.Info = Merge(o.Info, i.Info)
 
-	// if ok1 && !ok2 {
-	// 	err.Info.Inner = MergeErrors(o.Info.Inner, i)
-	// } else if !ok1 && ok2 {
-	// 	err.Info.Inner = MergeErrors(o, i.Info.Inner)
-	// }
+		err.Info.Inner = MergeErrors(outer, err.Info.Inner)
+	default:
+		return fmt.Errorf("%w: %w", outer, inner)
+	}
 
 	return err
 }
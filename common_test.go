@@ -0,0 +1,234 @@
+package errors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// new_merge_err creates an *Err with one of each mergeable field.
+func new_merge_err(code ErrorCode, name string) *Err {
+	err := New(code, name)
+	err.AddSuggestion(name + " suggestion")
+	err.AddContext("key", name)
+	err.AddContext(name, true)
+	err.AddFrame(name + "()")
+
+	return err
+}
+
+func TestMergeErrors(t *testing.T) {
+	var typed_nil *Err
+
+	foreign_outer := errors.New("foreign outer")
+	foreign_inner := errors.New("foreign inner")
+
+	tests := []struct {
+		name  string
+		outer func() error
+		inner func() error
+
+		// want_nil is true if the result must be nil.
+		want_nil bool
+
+		// want_msg is the expected Error() of the result.
+		want_msg string
+
+		// want_code is the expected code of the result, if it is an *Err.
+		want_code ErrorCoder
+
+		// want_causes are the errors the result must wrap.
+		want_causes []error
+	}{
+		{
+			name:     "nil/nil",
+			outer:    func() error { return nil },
+			inner:    func() error { return nil },
+			want_nil: true,
+		},
+		{
+			name:     "typed-nil/typed-nil",
+			outer:    func() error { return typed_nil },
+			inner:    func() error { return typed_nil },
+			want_nil: true,
+		},
+		{
+			name:      "nil/*Err",
+			outer:     func() error { return nil },
+			inner:     func() error { return New(NoSuchKey, "inner") },
+			want_msg:  "[ERROR] NoSuchKey: inner",
+			want_code: NoSuchKey,
+		},
+		{
+			name:      "*Err/nil",
+			outer:     func() error { return New(BadParameter, "outer") },
+			inner:     func() error { return nil },
+			want_msg:  "[ERROR] BadParameter: outer",
+			want_code: BadParameter,
+		},
+		{
+			name:      "typed-nil/*Err",
+			outer:     func() error { return typed_nil },
+			inner:     func() error { return New(NoSuchKey, "inner") },
+			want_msg:  "[ERROR] NoSuchKey: inner",
+			want_code: NoSuchKey,
+		},
+		{
+			name:      "*Err/typed-nil",
+			outer:     func() error { return New(BadParameter, "outer") },
+			inner:     func() error { return typed_nil },
+			want_msg:  "[ERROR] BadParameter: outer",
+			want_code: BadParameter,
+		},
+		{
+			name:        "nil/foreign",
+			outer:       func() error { return nil },
+			inner:       func() error { return foreign_inner },
+			want_msg:    "foreign inner",
+			want_causes: []error{foreign_inner},
+		},
+		{
+			name:      "*Err/*Err",
+			outer:     func() error { return new_merge_err(BadParameter, "outer") },
+			inner:     func() error { return new_merge_err(NoSuchKey, "inner") },
+			want_msg:  "[ERROR] BadParameter: outer",
+			want_code: BadParameter,
+		},
+		{
+			name: "*Err without info/templated *Err",
+			outer: func() error {
+				// NewFromError leaves errors like this one.
+				outer := New(BadParameter, "outer msg")
+				outer.Info = nil
+
+				return outer
+			},
+			inner:     func() error { return NewErrNoSuchKey("f()", "k") },
+			want_msg:  "[ERROR] BadParameter: outer msg",
+			want_code: BadParameter,
+		},
+		{
+			name:        "*Err/foreign",
+			outer:       func() error { return New(BadParameter, "outer") },
+			inner:       func() error { return foreign_inner },
+			want_msg:    "[ERROR] BadParameter: outer",
+			want_code:   BadParameter,
+			want_causes: []error{foreign_inner},
+		},
+		{
+			name:        "foreign/*Err",
+			outer:       func() error { return foreign_outer },
+			inner:       func() error { return New(NoSuchKey, "inner") },
+			want_msg:    "[ERROR] NoSuchKey: inner",
+			want_code:   NoSuchKey,
+			want_causes: []error{foreign_outer},
+		},
+		{
+			name:        "foreign/foreign",
+			outer:       func() error { return foreign_outer },
+			inner:       func() error { return foreign_inner },
+			want_msg:    "foreign outer: foreign inner",
+			want_causes: []error{foreign_outer, foreign_inner},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeErrors(tt.outer(), tt.inner())
+
+			if tt.want_nil {
+				if got != nil {
+					t.Fatalf("MergeErrors() = %v, want nil", got)
+				}
+
+				return
+			}
+
+			if got == nil {
+				t.Fatalf("MergeErrors() = nil, want %q", tt.want_msg)
+			}
+
+			if msg := got.Error(); msg != tt.want_msg {
+				t.Errorf("Error() = %q, want %q", msg, tt.want_msg)
+			}
+
+			if tt.want_code != nil {
+				e, ok := got.(*Err)
+				if !ok {
+					t.Fatalf("MergeErrors() = %T, want *Err", got)
+				}
+
+				if e.Code != tt.want_code {
+					t.Errorf("Code = %v, want %v", e.Code, tt.want_code)
+				}
+			}
+
+			for _, cause := range tt.want_causes {
+				if !errors.Is(got, cause) {
+					t.Errorf("errors.Is(MergeErrors(), %q) = false, want true", cause)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeErrorsInfo(t *testing.T) {
+	outer := new_merge_err(BadParameter, "outer")
+	inner := new_merge_err(NoSuchKey, "inner")
+
+	outer_callers := len(outer.Info.Callers)
+	inner_callers := len(inner.Info.Callers)
+
+	got, ok := MergeErrors(outer, inner).(*Err)
+	if !ok {
+		t.Fatalf("MergeErrors() is not an *Err")
+	}
+
+	if want := []string{"outer suggestion", "inner suggestion"}; !reflect.DeepEqual(got.Suggestions(), want) {
+		t.Errorf("Suggestions() = %v, want %v", got.Suggestions(), want)
+	}
+
+	if want := []string{"inner()", "outer()"}; !reflect.DeepEqual(got.Info.Frames, want) {
+		t.Errorf("Frames = %v, want %v", got.Info.Frames, want)
+	}
+
+	want_context := map[string]any{
+		"key":   "outer",
+		"outer": true,
+		"inner": true,
+	}
+
+	if !reflect.DeepEqual(got.Info.Context, want_context) {
+		t.Errorf("Context = %v, want %v", got.Info.Context, want_context)
+	}
+
+	if n := len(got.Info.Callers); n != outer_callers+inner_callers {
+		t.Errorf("len(Callers) = %d, want %d", n, outer_callers+inner_callers)
+	}
+
+	if !reflect.DeepEqual(got.Info.Callers[:inner_callers], inner.Info.Callers) {
+		t.Errorf("Callers do not start with the inner stack trace")
+	}
+
+	// Neither input is modified.
+	if len(outer.Info.Frames) != 1 || len(inner.Info.Frames) != 1 {
+		t.Errorf("MergeErrors() modified its inputs")
+	}
+
+	if outer.Info.Context["key"] != "outer" || inner.Info.Context["key"] != "inner" {
+		t.Errorf("MergeErrors() modified the contexts of its inputs")
+	}
+}
+
+func TestMergeNil(t *testing.T) {
+	if got := Merge(nil, nil); got == nil {
+		t.Errorf("Merge(nil, nil) = nil, want a new Info")
+	}
+
+	inner := New(NoSuchKey, "inner")
+
+	got := Merge(nil, inner.Info)
+	if got == inner.Info {
+		t.Errorf("Merge(nil, inner) returned inner instead of a copy")
+	}
+}
@@ -0,0 +1,186 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// context_code returns the code that corresponds to a context error.
+//
+// Parameters:
+//   - err: The error to check.
+//
+// Returns:
+//   - ErrorCode: Canceled or DeadlineExceeded.
+//   - bool: True if err is or wraps a context error, false otherwise.
+func context_code(err error) (ErrorCode, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return DeadlineExceeded, true
+	} else if errors.Is(err, context.Canceled) {
+		return Canceled, true
+	}
+
+	return OperationFail, false
+}
+
+// FromContext creates a new error from a context that is done.
+//
+// Parameters:
+//   - ctx: The context.
+//
+// Returns:
+//   - *Err: A pointer to the new error. Nil if ctx is nil or not done.
+//
+// The code is Canceled or DeadlineExceeded, depending on ctx.Err(), and the
+// inner error is always ctx.Err(), so that errors.Is(err, context.Canceled)
+// holds. If the context was canceled with a distinct cause (see
+// context.WithCancelCause), the inner error joins ctx.Err() and the cause
+// (see errors.Join). Values extracted from ctx are added to the context of
+// the error (see AddContextFrom).
+func FromContext(ctx context.Context) *Err {
+	if ctx == nil || ctx.Err() == nil {
+		return nil
+	}
+
+	ctx_err := ctx.Err()
+
+	code, _ := context_code(ctx_err)
+
+	err := &Err{
+		Severity: ERROR,
+		Code:     code,
+		Message:  ctx_err.Error(),
+		Info:     internal.NewInfo(),
+	}
+
+	cause := context.Cause(ctx)
+	if cause != nil && cause != ctx_err {
+		err.Info.Inner = errors.Join(ctx_err, cause)
+	} else {
+		err.Info.Inner = ctx_err
+	}
+
+	err.Info.Callers = capture_callers(1)
+
+	err.AddContextFrom(ctx)
+
+	return err
+}
+
+// ContextExtractor extracts a value from a context.
+//
+// Parameters:
+//   - ctx: The context. Never nil.
+//
+// Returns:
+//   - any: The extracted value.
+//   - bool: True if the value was found, false otherwise.
+type ContextExtractor func(ctx context.Context) (any, bool)
+
+// ContextKey returns an extractor that reads the value stored under the key
+// with context.WithValue.
+//
+// Parameters:
+//   - key: The key of the value.
+//
+// Returns:
+//   - ContextExtractor: The extractor. Never returns nil.
+func ContextKey(key any) ContextExtractor {
+	return func(ctx context.Context) (any, bool) {
+		value := ctx.Value(key)
+		return value, value != nil
+	}
+}
+
+var (
+	// extractors_mu protects extractors.
+	extractors_mu sync.RWMutex
+
+	// extractors maps a context key of the error to its extractor.
+	extractors map[string]ContextExtractor
+)
+
+// RegisterContextExtractor registers an extractor whose value is added to
+// the context of an error under the given key by AddContextFrom.
+//
+// Parameters:
+//   - key: The key of the value in the context of the error (e.g.,
+//     "request_id" or "trace_id").
+//   - fn: The extractor.
+//
+// Returns:
+//   - error: An error if the registration failed.
+//
+// Errors:
+//   - *Err with code BadParameter: If the key is empty or fn is nil.
+//   - *Err with code InvalidUsage: If the key is already registered.
+func RegisterContextExtractor(key string, fn ContextExtractor) error {
+	if key == "" {
+		return NewErrInvalidParameter("RegisterContextExtractor()", "key must not be empty")
+	} else if fn == nil {
+		return NewErrNilParameter("RegisterContextExtractor()", "fn")
+	}
+
+	extractors_mu.Lock()
+	defer extractors_mu.Unlock()
+
+	if _, ok := extractors[key]; ok {
+		return NewErrInvalidUsage("RegisterContextExtractor()", "key ("+strconv.Quote(key)+") is already registered", "Use a different key for each extractor")
+	}
+
+	if extractors == nil {
+		extractors = make(map[string]ContextExtractor)
+	}
+
+	extractors[key] = fn
+
+	return nil
+}
+
+// AddContextFrom adds the values extracted from ctx by the registered
+// extractors (see RegisterContextExtractor) to the context of the error.
+// Does nothing if the receiver or ctx is nil.
+//
+// Parameters:
+//   - ctx: The context to extract the values from.
+//
+// Keys that are already part of the context of the error are not
+// overwritten.
+func (e *Err) AddContextFrom(ctx context.Context) {
+	if e == nil || ctx == nil {
+		return
+	}
+
+	extractors_mu.RLock()
+
+	keys := make([]string, 0, len(extractors))
+	for key := range extractors {
+		keys = append(keys, key)
+	}
+
+	fns := make([]ContextExtractor, 0, len(keys))
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fns = append(fns, extractors[key])
+	}
+
+	extractors_mu.RUnlock()
+
+	for i, fn := range fns {
+		if _, ok := e.Value(keys[i]); ok {
+			continue
+		}
+
+		value, ok := fn(ctx)
+		if ok {
+			e.AddContext(keys[i], value)
+		}
+	}
+}
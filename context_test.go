@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromContext(t *testing.T) {
+	cause := errors.New("shutting down")
+
+	tests := []struct {
+		name      string
+		ctx       func(t *testing.T) context.Context
+		want_code ErrorCode
+		want_is   []error
+	}{
+		{
+			name: "canceled",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			},
+			want_code: Canceled,
+			want_is:   []error{context.Canceled},
+		},
+		{
+			name: "canceled with cause",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(cause)
+
+				return ctx
+			},
+			want_code: Canceled,
+			want_is:   []error{context.Canceled, cause},
+		},
+		{
+			name: "canceled with nil cause",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(nil)
+
+				return ctx
+			},
+			want_code: Canceled,
+			want_is:   []error{context.Canceled},
+		},
+		{
+			name: "deadline exceeded",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithDeadline(context.Background(), time.Unix(0, 0))
+				t.Cleanup(cancel)
+
+				return ctx
+			},
+			want_code: DeadlineExceeded,
+			want_is:   []error{context.DeadlineExceeded},
+		},
+		{
+			name: "deadline exceeded with cause",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithDeadlineCause(context.Background(), time.Unix(0, 0), cause)
+				t.Cleanup(cancel)
+
+				return ctx
+			},
+			want_code: DeadlineExceeded,
+			want_is:   []error{context.DeadlineExceeded, cause},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromContext(tt.ctx(t))
+			if err == nil {
+				t.Fatalf("FromContext() = nil, want an error")
+			}
+
+			if err.Code != tt.want_code {
+				t.Errorf("Code = %v, want %v", err.Code, tt.want_code)
+			}
+
+			for _, target := range tt.want_is {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is(FromContext(), %q) = false, want true", target)
+				}
+			}
+		})
+	}
+}
+
+func TestFromContextNotDone(t *testing.T) {
+	if err := FromContext(context.Background()); err != nil {
+		t.Errorf("FromContext(Background()) = %v, want nil", err)
+	}
+
+	if err := FromContext(nil); err != nil {
+		t.Errorf("FromContext(nil) = %v, want nil", err)
+	}
+}
+
+func TestRetryCanceledIsContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Retry(ctx, func(ctx context.Context) error {
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("errors.Is(Retry(), context.Canceled) = false, want true")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	r := Retrier{
+		MaxAttempts: 5,
+		Clock: &fake_clock{
+			on_after: cancel,
+			block:    true,
+		},
+	}
+
+	err = r.Retry(ctx, func(ctx context.Context) error {
+		err := New(OperationFail, "fail")
+		err.MarkTransient(0)
+
+		return err
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("errors.Is(Retrier.Retry(), context.Canceled) = false, want true")
+	}
+}
@@ -2,84 +2,168 @@ package errors
 
 import (
 	"bytes"
+	"cmp"
+	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/PlayerR9/go-errors/internal"
 )
 
-// display_info displays the info to the writer.
+// display_info writes the info into the builder.
 //
 // Parameters:
+//   - b: The builder to write into.
 //   - info: The info to display.
-//   - w: The writer to write to.
+//   - st: The style to use.
 //
 // Returns:
 //   - error: The error that occurred while displaying the info.
-func display_info(info *internal.Info, w io.Writer) error {
+func display_info(b *strings.Builder, info *internal.Info, st text_style) error {
 	if info == nil {
 		return nil
 	}
 
-	var b bytes.Buffer
-
 	// if !info.Timestamp.IsZero() {
-	// 	fmt.Fprintf(&b, "Occurred at: %v\n", info.Timestamp)
+	// 	fmt.Fprintf(b, "Occurred at: %v\n", info.Timestamp)
 	// }
 
-	// if len(info.Suggestions) > 0 {
-	// 	fmt.Fprintf(&b, "Suggestion: \n")
+	if len(info.Suggestions) > 0 {
+		b.WriteString(paint("Suggestion:", ansi_bold, st.color) + " \n")
 
-	// 	for _, suggestion := range info.Suggestions {
-	// 		fmt.Fprintf(&b, "- %s\n", suggestion)
-	// 	}
-	// }
+		for _, suggestion := range info.Suggestions {
+			b.WriteString(wrap_text("- "+suggestion, st.width, "  ") + "\n")
+		}
+	}
 
-	// if len(info.Context) > 0 {
-	// 	b.WriteString("\nContext:\n")
+	if len(info.Context) > 0 {
+		b.WriteString("\n" + paint("Context:", ansi_bold, st.color) + "\n")
 
-	// 	for k, v := range info.Context {
-	// 		fmt.Fprintf(&b, "- %s: %v\n", k, v)
-	// 	}
-	// }
+		keys := slices.Sorted(maps.Keys(info.Context))
 
-	// if info.StackTrace != nil {
-	// 	fmt.Fprintf(&b, "\nStack trace:\n")
+		for _, k := range keys {
+			b.WriteString(wrap_text(fmt.Sprintf("- %s: %v", k, info.Context[k]), st.width, "  ") + "\n")
+		}
+	}
 
-	// 	elem := make([]string, len(info.StackTrace))
-	// 	copy(elem, info.StackTrace)
+	if st.verbose && (len(info.Frames) > 0 || len(info.Callers) > 0) {
+		b.WriteString("\n" + paint("Stack trace:", ansi_bold, st.color) + "\n")
 
-	// 	slices.Reverse(elem)
+		if len(info.Frames) > 0 {
+			elem := make([]string, len(info.Frames))
+			copy(elem, info.Frames)
 
-	// 	fmt.Fprintf(&b, "- %s\n", strings.Join(elem, " <- "))
-	// }
+			slices.Reverse(elem)
 
-	// if info.Inner != nil {
-	// 	fmt.Fprintf(&b, "\nCaused by:\n")
+			fmt.Fprintf(b, "- %s\n", strings.Join(elem, " <- "))
+		}
 
-	// 	err := DisplayError(&b, info.Inner)
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
+		for _, frame := range resolve_frames(info.Callers) {
+			fmt.Fprintf(b, "- %s\n", frame)
+		}
+	}
 
-	data := b.Bytes()
+	if info.Inner != nil {
+		b.WriteString("\n" + paint("Caused by:", ansi_bold, st.color) + "\n")
 
-	if len(data) == 0 {
-		return nil
-	} else if w == nil {
-		return io.ErrShortWrite
+		var causes []error
+
+		joined, ok := info.Inner.(interface{ Unwrap() []error })
+		if ok {
+			causes = joined.Unwrap()
+		} else {
+			causes = []error{info.Inner}
+		}
+
+		for _, cause := range causes {
+			err := display_error(b, cause, st)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	n, err := w.Write(data)
-	if err != nil {
-		return err
-	} else if n != len(data) {
-		return io.ErrShortWrite
+	return nil
+}
+
+// display_aggregate writes the errors of the aggregate into the builder,
+// grouped by severity level from the highest to the lowest.
+//
+// Parameters:
+//   - b: The builder to write into.
+//   - agg: The aggregate to display.
+//   - st: The style to use.
+//
+// Returns:
+//   - error: The error that occurred while displaying the aggregate.
+func display_aggregate(b *strings.Builder, agg *Aggregate, st text_style) error {
+	errs := agg.Errors()
+
+	fmt.Fprintf(b, "%d error(s) occurred\n", len(errs))
+
+	groups := make(map[SeverityLevel][]error)
+
+	for _, err := range errs {
+		level := severity_of(err)
+		groups[level] = append(groups[level], err)
+	}
+
+	levels := slices.Sorted(maps.Keys(groups))
+	slices.Reverse(levels)
+
+	for _, level := range levels {
+		group := groups[level]
+
+		b.WriteString("\n" + paint(fmt.Sprintf("%v (%d):", level, len(group)), severity_colors[level], st.color) + "\n")
+
+		for _, err := range group {
+			err := display_error(b, err, st)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// display_error writes the complete error into the builder.
+//
+// Parameters:
+//   - b: The builder to write into.
+//   - to_display: The error to display. Assumed to be non-nil.
+//   - st: The style to use.
+//
+// Returns:
+//   - error: The error that occurred while displaying the error.
+func display_error(b *strings.Builder, to_display error, st text_style) error {
+	if agg, ok := to_display.(*Aggregate); ok {
+		return display_aggregate(b, agg, st)
+	}
+
+	e, ok := to_display.(*Err)
+	if !ok || e == nil {
+		b.WriteString(wrap_text(to_display.Error(), st.width, "  ") + "\n")
+		return nil
+	}
+
+	header := wrap_text(e.Error(), st.width, "  ")
+
+	if st.color {
+		prefix := "[" + e.Severity.String() + "]"
+
+		header = paint(prefix, severity_colors[e.Severity], true) + strings.TrimPrefix(header, prefix)
+	}
+
+	b.WriteString(header + "\n")
+
+	return display_info(b, e.Info, st)
+}
+
 // Panic is like DisplayError but panics afterwards.
 //
 // Parameters:
@@ -90,18 +174,16 @@ func Panic(w io.Writer, to_display error) {
 		return
 	}
 
-	e, ok := to_display.(*Err)
-	if ok && e.Info != nil {
-		err := display_info(e.Info, w)
-		if err != nil {
-			panic(err)
-		}
+	err := DefaultRenderer().Render(w, to_display)
+	if err != nil {
+		panic(err)
 	}
 
 	panic(to_display)
 }
 
-// DisplayError displays the complete error to the writer.
+// DisplayError displays the complete error to the writer with the default
+// renderer (see SetDefaultRenderer).
 //
 // Parameters:
 //   - w: The writer to write to.
@@ -109,33 +191,342 @@ func Panic(w io.Writer, to_display error) {
 //
 // Returns:
 //   - error: The error that occurred while displaying the error.
+//
+// The initial default renderer is the "text" TextRenderer, which omits stack
+// traces and uses colours and line wrapping only when w is a terminal.
 func DisplayError(w io.Writer, to_display error) error {
+	return DefaultRenderer().Render(w, to_display)
+}
+
+// SourceFunc returns the content of a source file.
+//
+// Parameters:
+//   - file: The path of the source file.
+//
+// Returns:
+//   - []byte: The content of the file.
+//   - bool: True if the content is available, false otherwise.
+type SourceFunc func(file string) ([]byte, bool)
+
+// resolved_span is a span whose position has been resolved against its
+// source.
+type resolved_span struct {
+	Span
+
+	// text is the text of the line of the span. Empty if the source is not
+	// available.
+	text string
+
+	// has_text is true if the source of the span is available.
+	has_text bool
+
+	// primary is true if the span is the primary one.
+	primary bool
+}
+
+// resolve_span resolves the line, column and line text of a span.
+//
+// Parameters:
+//   - span: The span to resolve.
+//   - data: The content of the source file.
+//
+// Returns:
+//   - resolved_span: The resolved span.
+func resolve_span(span Span, data []byte) resolved_span {
+	rs := resolved_span{
+		Span: span,
+	}
+
+	var line_start int
+
+	if span.Line <= 0 {
+		if span.Offset < 0 || span.Offset > len(data) {
+			return rs
+		}
+
+		line_start = bytes.LastIndexByte(data[:span.Offset], '\n') + 1
+		rs.Line = bytes.Count(data[:line_start], []byte("\n")) + 1
+		rs.Column = span.Offset - line_start + 1
+	} else {
+		line := 1
+
+		for line < span.Line {
+			idx := bytes.IndexByte(data[line_start:], '\n')
+			if idx < 0 {
+				return rs
+			}
+
+			line_start += idx + 1
+			line++
+		}
+
+		if rs.Column <= 0 {
+			rs.Column = 1
+		}
+	}
+
+	line_end := bytes.IndexByte(data[line_start:], '\n')
+	if line_end < 0 {
+		line_end = len(data)
+	} else {
+		line_end += line_start
+	}
+
+	rs.text = strings.TrimSuffix(string(data[line_start:line_end]), "\r")
+	rs.has_text = true
+
+	return rs
+}
+
+// underline returns the padding and the underline of the span.
+//
+// Returns:
+//   - string: The padding, made of spaces and the tabs of the line text.
+//   - string: The underline.
+func (rs resolved_span) underline() (string, string) {
+	start := min(rs.Column-1, len(rs.text))
+
+	var padding strings.Builder
+
+	for _, r := range rs.text[:start] {
+		if r == '\t' {
+			padding.WriteRune('\t')
+		} else {
+			padding.WriteRune(' ')
+		}
+	}
+
+	end := min(start+max(rs.Length, 1), len(rs.text))
+
+	size := utf8.RuneCountInString(rs.text[start:end])
+	if size == 0 {
+		size = 1
+	}
+
+	if rs.primary {
+		return padding.String(), "^" + strings.Repeat("~", size-1)
+	}
+
+	return padding.String(), strings.Repeat("-", size)
+}
+
+// compare_spans orders two secondary spans: the spans of the file of the
+// primary span first, then by file, line and column.
+//
+// Parameters:
+//   - a: The first span.
+//   - b: The second span.
+//   - primary_file: The file of the primary span.
+//
+// Returns:
+//   - int: A negative number if a comes first, a positive number if b comes
+//     first and 0 otherwise.
+func compare_spans(a, b resolved_span, primary_file string) int {
+	a_primary := a.File == primary_file
+	b_primary := b.File == primary_file
+
+	switch {
+	case a_primary && !b_primary:
+		return -1
+	case !a_primary && b_primary:
+		return 1
+	}
+
+	return cmp.Or(
+		cmp.Compare(a.File, b.File),
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(a.Column, b.Column),
+	)
+}
+
+// DisplayDiagnostic displays the error in the style of a compiler diagnostic:
+// a header with the severity, the code and the message, the lines of source
+// pointed to by the spans of the error with their underlines and labels, and
+// the suggestions as notes.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - to_display: The error to display.
+//   - source: The function that provides the content of source files. If
+//     nil, no source line is displayed.
+//   - color: Whether to use ANSI colours.
+//
+// Returns:
+//   - error: The error that occurred while displaying the error.
+//
+// The primary span is displayed first and the other spans are sorted by
+// file, starting with the file of the primary span, then by line and column.
+// Errors that are not *Err or that have no spans are displayed with
+// DisplayError.
+//
+// Example:
+//
+//	error[NoSuchKey]: key ("foo") does not exist
+//	 --> main.cfg:3:5
+//	  |
+//	3 | x = foo
+//	  |     ^~~ not defined
+//	  |
+//	  = note: Did you mean "fox"?
+func DisplayDiagnostic(w io.Writer, to_display error, source SourceFunc, color bool) error {
 	if to_display == nil {
 		return nil
 	} else if w == nil {
 		return io.ErrShortWrite
 	}
 
-	data := []byte(to_display.Error())
+	e, ok := to_display.(*Err)
+	if !ok || e == nil || e.Info == nil || len(e.Info.Spans) == 0 {
+		return DisplayError(w, to_display)
+	}
 
-	n, err := w.Write(data)
-	if err != nil {
-		return err
-	} else if n != len(data) {
-		return io.ErrShortWrite
+	var b bytes.Buffer
+
+	severity := strings.ToLower(e.Severity.String())
+
+	b.WriteString(paint(severity, severity_colors[e.Severity], color))
+
+	if e.Code != nil {
+		b.WriteString(paint("["+code_label(e.Code)+"]", severity_colors[e.Severity], color))
 	}
 
-	e, ok := to_display.(*Err)
-	if !ok || e.Info == nil {
-		return nil
+	msg := e.RenderMessage()
+	if msg == "" {
+		msg = "[no message was provided]"
+	}
+
+	b.WriteString(paint(": "+msg, ansi_bold, color))
+	b.WriteByte('\n')
+
+	cache := make(map[string][]byte)
+
+	spans := make([]resolved_span, 0, len(e.Info.Spans))
+
+	for i, span := range e.Info.Spans {
+		data, ok := cache[span.File]
+		if !ok && source != nil {
+			data, ok = source(span.File)
+			if ok {
+				cache[span.File] = data
+			}
+		}
+
+		var rs resolved_span
+
+		if ok {
+			rs = resolve_span(span, data)
+		} else {
+			rs = resolved_span{Span: span}
+		}
+
+		rs.primary = i == 0
+
+		spans = append(spans, rs)
+	}
+
+	slices.SortStableFunc(spans[1:], func(a, b resolved_span) int {
+		return compare_spans(a, b, spans[0].File)
+	})
+
+	var width int
+
+	for _, rs := range spans {
+		width = max(width, len(strconv.Itoa(rs.Line)))
+	}
+
+	gutter := strings.Repeat(" ", width) + " " + paint("|", ansi_blue, color)
+
+	fmt.Fprintf(&b, "%s%s %s\n", strings.Repeat(" ", width), paint("-->", ansi_blue, color), spans[0].Span)
+
+	prev_file := spans[0].File
+	prev_line := -1
+
+	b.WriteString(gutter + "\n")
+
+	for _, rs := range spans {
+		if rs.File != prev_file {
+			fmt.Fprintf(&b, "%s%s %s\n", strings.Repeat(" ", width), paint(":::", ansi_blue, color), rs.Span)
+			b.WriteString(gutter + "\n")
+
+			prev_file = rs.File
+			prev_line = -1
+		}
+
+		if !rs.has_text {
+			label := rs.Label
+			if label == "" {
+				label = "here"
+			}
+
+			fmt.Fprintf(&b, "%s %s: %s\n", gutter, rs.Span, label)
+			continue
+		}
+
+		if rs.Line != prev_line {
+			line_no := strconv.Itoa(rs.Line)
+
+			fmt.Fprintf(&b, "%s%s %s %s\n", strings.Repeat(" ", width-len(line_no)), paint(line_no, ansi_blue, color), paint("|", ansi_blue, color), rs.text)
+
+			prev_line = rs.Line
+		}
+
+		padding, mark := rs.underline()
+
+		attrs := ansi_blue
+		if rs.primary {
+			attrs = severity_colors[e.Severity]
+		}
+
+		line := gutter + " " + padding + paint(mark, attrs, color)
+
+		if rs.Label != "" {
+			line += " " + paint(rs.Label, attrs, color)
+		}
+
+		b.WriteString(line + "\n")
 	}
 
-	err = display_info(e.Info, w)
+	if len(e.Info.Suggestions) > 0 {
+		b.WriteString(gutter + "\n")
+
+		for _, suggestion := range e.Info.Suggestions {
+			fmt.Fprintf(&b, "%s %s %s\n", strings.Repeat(" ", width), paint("=", ansi_blue, color), paint("note:", ansi_bold, color)+" "+suggestion)
+		}
+	}
+
+	if e.Info.Inner != nil {
+		b.WriteString("\nCaused by:\n")
+
+		err := DisplayDiagnostic(&b, e.Info.Inner, source, color)
+		if err != nil {
+			return err
+		}
+	}
+
+	data := b.Bytes()
+
+	n, err := w.Write(data)
 	if err != nil {
 		return err
+	} else if n != len(data) {
+		return io.ErrShortWrite
 	}
 
 	return nil
 }
 
-///////////////////////////
+// DiagnosticRenderer renders errors with DisplayDiagnostic.
+type DiagnosticRenderer struct {
+	// Source provides the content of source files. May be nil.
+	Source SourceFunc
+
+	// Color decides whether ANSI colours are used.
+	Color ColorMode
+}
+
+// Render implements the Renderer interface.
+func (r DiagnosticRenderer) Render(w io.Writer, to_display error) error {
+	st := TextRenderer{Color: r.Color}.style(w)
+
+	return DisplayDiagnostic(w, to_display, r.Source, st.color)
+}
@@ -0,0 +1,182 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestResolveSpan(t *testing.T) {
+	data := []byte("x = foo\r\n\ty = bar\nz = baz")
+
+	tests := []struct {
+		name     string
+		span     Span
+		line     int
+		column   int
+		text     string
+		has_text bool
+	}{
+		{"offset start", Span{Offset: 0}, 1, 1, "x = foo", true},
+		{"offset first line", Span{Offset: 4}, 1, 5, "x = foo", true},
+		{"offset after CRLF", Span{Offset: 9}, 2, 1, "\ty = bar", true},
+		{"offset after tab", Span{Offset: 14}, 2, 6, "\ty = bar", true},
+		{"offset last line", Span{Offset: 22}, 3, 5, "z = baz", true},
+		{"offset end", Span{Offset: 25}, 3, 8, "z = baz", true},
+		{"offset out of range", Span{Offset: 26}, 0, 0, "", false},
+		{"negative offset", Span{Offset: -1}, 0, 0, "", false},
+		{"line and column", Span{Line: 2, Column: 3}, 2, 3, "\ty = bar", true},
+		{"line without column", Span{Line: 3}, 3, 1, "z = baz", true},
+		{"line out of range", Span{Line: 4, Column: 1}, 4, 1, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := resolve_span(tt.span, data)
+
+			if rs.Line != tt.line || rs.Column != tt.column {
+				t.Errorf("position = %d:%d, want %d:%d", rs.Line, rs.Column, tt.line, tt.column)
+			}
+
+			if rs.text != tt.text || rs.has_text != tt.has_text {
+				t.Errorf("text = (%q, %t), want (%q, %t)", rs.text, rs.has_text, tt.text, tt.has_text)
+			}
+		})
+	}
+}
+
+func TestUnderline(t *testing.T) {
+	tests := []struct {
+		name    string
+		rs      resolved_span
+		padding string
+		mark    string
+	}{
+		{"primary", resolved_span{Span: Span{Column: 5, Length: 3}, text: "x = foo", primary: true}, "    ", "^~~"},
+		{"secondary", resolved_span{Span: Span{Column: 5, Length: 3}, text: "x = foo"}, "    ", "---"},
+		{"tabs", resolved_span{Span: Span{Column: 3, Length: 1}, text: "\t\ty", primary: true}, "\t\t", "^"},
+		{"empty length", resolved_span{Span: Span{Column: 1}, text: "abc"}, "", "-"},
+		{"past end", resolved_span{Span: Span{Column: 9, Length: 4}, text: "abc", primary: true}, "   ", "^"},
+		{"multibyte", resolved_span{Span: Span{Column: 1, Length: 4}, text: "éé!"}, "", "--"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			padding, mark := tt.rs.underline()
+			if padding != tt.padding || mark != tt.mark {
+				t.Errorf("underline() = (%q, %q), want (%q, %q)", padding, mark, tt.padding, tt.mark)
+			}
+		})
+	}
+}
+
+// test_source is a SourceFunc that serves the files of a map.
+func test_source(files map[string]string) SourceFunc {
+	return func(file string) ([]byte, bool) {
+		data, ok := files[file]
+		return []byte(data), ok
+	}
+}
+
+func TestDisplayDiagnostic(t *testing.T) {
+	source := test_source(map[string]string{
+		"a.cfg": "x = foo\r\n\ty = bar\n",
+		"b.cfg": "one\ntwo\n",
+	})
+
+	sorted := New(NoSuchKey, `key ("foo") does not exist`)
+	sorted.AddSpan(Span{File: "a.cfg", Offset: 4, Length: 3, Label: "not defined"})
+	sorted.AddSpan(Span{File: "b.cfg", Line: 2, Column: 1, Length: 3, Label: "used here"})
+	sorted.AddSpan(Span{File: "a.cfg", Offset: 14, Length: 3, Label: "later"})
+	sorted.AddSpan(Span{File: "a.cfg", Line: 1, Column: 1, Length: 1, Label: "earlier"})
+	sorted.AddSpan(Span{File: "b.cfg", Line: 1, Column: 1, Length: 3})
+	sorted.AddSuggestion(`Did you mean "fox"?`)
+
+	no_source := NewWithSeverity(WARNING, BadParameter, "bad value")
+	no_source.AddSpan(Span{File: "c.cfg", Offset: 3})
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "sorted spans",
+			err:  sorted,
+			want: []string{
+				`error[NoSuchKey]: key ("foo") does not exist`,
+				` --> a.cfg:1:5`,
+				`  |`,
+				`1 | x = foo`,
+				`  |     ^~~ not defined`,
+				`  | - earlier`,
+				"2 | \ty = bar",
+				"  | \t    --- later",
+				` ::: b.cfg:1:1`,
+				`  |`,
+				`1 | one`,
+				`  | ---`,
+				`2 | two`,
+				`  | --- used here`,
+				`  |`,
+				`  = note: Did you mean "fox"?`,
+			},
+		},
+		{
+			name: "no source",
+			err:  no_source,
+			want: []string{
+				`warning[BadParameter]: bad value`,
+				` --> c.cfg:+3`,
+				`  |`,
+				`  | c.cfg:+3: here`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := DisplayDiagnostic(&buf, tt.err, source, false)
+			if err != nil {
+				t.Fatalf("DisplayDiagnostic() = %v", err)
+			}
+
+			want := strings.Join(tt.want, "\n") + "\n"
+
+			if got := buf.String(); got != want {
+				t.Errorf("DisplayDiagnostic() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestDisplayDiagnosticFallback(t *testing.T) {
+	disable_stack_capture(t)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"foreign", fmt.Errorf("plain"), "plain"},
+		{"no spans", New(BadParameter, "bad"), "[ERROR] BadParameter: bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := DisplayDiagnostic(&buf, tt.err, nil, false)
+			if err != nil {
+				t.Fatalf("DisplayDiagnostic() = %v", err)
+			}
+
+			if got := buf.String(); !strings.Contains(got, tt.want) || strings.Contains(got, "-->") {
+				t.Errorf("DisplayDiagnostic() = %q, want the output of DisplayError", got)
+			}
+		})
+	}
+}
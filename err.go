@@ -1,7 +1,10 @@
 package errors
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/PlayerR9/go-errors/internal"
 )
@@ -26,15 +29,59 @@ func (e *Err) Error() string {
 		return ""
 	}
 
-	var msg string
-
-	if e.Message == "" {
+	msg := e.RenderMessage()
+	if msg == "" {
 		msg = "[no message was provided]"
-	} else {
-		msg = e.Message
 	}
 
-	return fmt.Sprintf("[%v] %v: %s", e.Severity, e.Code, msg)
+	return fmt.Sprintf("[%v] %s: %s", e.Severity, code_label(e.Code), msg)
+}
+
+// Format implements the fmt.Formatter interface.
+//
+// Verbs:
+//   - %v, %s: The short form, as returned by Error.
+//   - %+v: The complete form, with stack traces, as written by
+//     TextRenderer{Verbose: true}.
+//   - %#v: A Go-syntax representation of the error.
+//   - %q: The message of the error, double-quoted.
+func (e *Err) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('#') {
+			if e == nil {
+				io.WriteString(s, "(*errors.Err)(nil)")
+			} else {
+				var code string
+
+				if e.Code == nil {
+					code = "nil"
+				} else {
+					code = fmt.Sprintf("%T(%d)", e.Code, e.Code.Int())
+				}
+
+				fmt.Fprintf(s, "&errors.Err{Severity:errors.SeverityLevel(%d), Code:%s, Message:%q, Info:%#v}", e.Severity, code, e.Message, e.Info)
+			}
+		} else if s.Flag('+') {
+			var b bytes.Buffer
+
+			_ = TextRenderer{Verbose: true}.Render(&b, e)
+
+			s.Write(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+		} else {
+			io.WriteString(s, e.Error())
+		}
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		if e == nil {
+			fmt.Fprintf(s, "%q", "")
+		} else {
+			fmt.Fprintf(s, "%q", e.RenderMessage())
+		}
+	default:
+		fmt.Fprintf(s, "%%!%c(*errors.Err=%s)", verb, e.Error())
+	}
 }
 
 // IsNil implements the Pointer interface.
@@ -51,11 +98,14 @@ func (e *Err) IsNil() bool {
 // Returns:
 //   - *Err: A pointer to the new error. Never returns nil.
 func New[C ErrorCoder](code C, message string) *Err {
+	info := internal.NewInfo()
+	info.Callers = capture_callers(1)
+
 	return &Err{
 		Severity: ERROR,
 		Code:     code,
 		Message:  message,
-		Info:     internal.NewInfo(),
+		Info:     info,
 	}
 }
 
@@ -69,11 +119,14 @@ func New[C ErrorCoder](code C, message string) *Err {
 // Returns:
 //   - *Err: A pointer to the new error. Never returns nil.
 func NewWithSeverity[C ErrorCoder](severity SeverityLevel, code C, message string) *Err {
+	info := internal.NewInfo()
+	info.Callers = capture_callers(1)
+
 	return &Err{
 		Severity: severity,
 		Code:     code,
 		Message:  message,
-		Info:     internal.NewInfo(),
+		Info:     info,
 	}
 }
 
@@ -100,7 +153,26 @@ func (e *Err) AddSuggestion(suggestion string) {
 		return
 	}
 
-	// e.Suggestions = append(e.Suggestions, suggestion)
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	e.Info.Suggestions = append(e.Info.Suggestions, suggestion)
+}
+
+// Suggestions returns the suggestions of the error.
+//
+// Returns:
+//   - []string: A copy of the suggestions of the error. Nil if there are none.
+func (e *Err) Suggestions() []string {
+	if e == nil || e.Info == nil || len(e.Info.Suggestions) == 0 {
+		return nil
+	}
+
+	suggestions := make([]string, len(e.Info.Suggestions))
+	copy(suggestions, e.Info.Suggestions)
+
+	return suggestions
 }
 
 // AddContext adds a context to the error. Does nothing if the
@@ -114,14 +186,17 @@ func (e *Err) AddContext(key string, value any) {
 		return
 	}
 
-	// if e.Context == nil {
-	// 	e.Context = make(map[string]any)
-	// }
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
 
-	// e.Context[key] = value
+	if e.Info.Context == nil {
+		e.Info.Context = make(map[string]any)
+	}
+
+	e.Info.Context[key] = value
 }
 
-/*
 // Value returns the value of the context with the given key.
 //
 // Parameters:
@@ -130,14 +205,14 @@ func (e *Err) AddContext(key string, value any) {
 // Returns:
 //   - any: The value of the context with the given key.
 //   - bool: true if the context contains the key, false otherwise.
-func (e Err) Value(key string) (any, bool) {
-	// if len(e.Context) == 0 {
-	// 	return nil, false
-	// }
+func (e *Err) Value(key string) (any, bool) {
+	if e == nil || e.Info == nil || len(e.Info.Context) == 0 {
+		return nil, false
+	}
 
-	// value, ok := e.Context[key]
-	// return value, ok
-} */
+	value, ok := e.Info.Context[key]
+	return value, ok
+}
 
 // AddFrame prepends a frame to the stack trace. Does nothing
 // if the receiver is nil or the trace is empty.
@@ -152,7 +227,26 @@ func (e *Err) AddFrame(frame string) {
 		return
 	}
 
-	// e.StackTrace = append(e.StackTrace, frame)
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	e.Info.Frames = append(e.Info.Frames, frame)
+}
+
+// StackTrace returns the stack trace captured when the error was created.
+//
+// Returns:
+//   - []Frame: The resolved frames, innermost first. Nil if no stack trace
+//     was captured.
+//
+// The frames are resolved lazily on each call.
+func (e *Err) StackTrace() []Frame {
+	if e == nil || e.Info == nil {
+		return nil
+	}
+
+	return resolve_frames(e.Info.Callers)
 }
 
 // SetInner sets the inner error. Does nothing if the receiver is nil.
@@ -164,7 +258,48 @@ func (e *Err) SetInner(inner error) {
 		return
 	}
 
-	// e.Inner = inner
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	e.Info.Inner = inner
+}
+
+// AddInner adds an inner error alongside the existing ones. Does nothing
+// if the receiver is nil or inner is nil.
+//
+// Parameters:
+//   - inner: The inner error to add.
+//
+// When more than one inner error is set, they are joined with errors.Join
+// so that Unwrap returns an error implementing Unwrap() []error.
+func (e *Err) AddInner(inner error) {
+	if e == nil || inner == nil {
+		return
+	}
+
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	if e.Info.Inner == nil {
+		e.Info.Inner = inner
+	} else {
+		e.Info.Inner = errors.Join(e.Info.Inner, inner)
+	}
+}
+
+// Unwrap returns the inner error so that errors.Is and errors.As can
+// walk through the error.
+//
+// Returns:
+//   - error: The inner error. Nil if there is none.
+func (e *Err) Unwrap() error {
+	if e == nil || e.Info == nil {
+		return nil
+	}
+
+	return e.Info.Inner
 }
 
 // NewFromError creates a new error from an error.
@@ -175,6 +310,10 @@ func (e *Err) SetInner(inner error) {
 //
 // Returns:
 //   - *Err: A pointer to the new error. Never returns nil.
+//
+// If err is not an *Err and is or wraps context.Canceled or
+// context.DeadlineExceeded, the code is replaced by Canceled or
+// DeadlineExceeded, respectively.
 func NewFromError[C ErrorCoder](code C, err error) *Err {
 	var outer *Err
 
@@ -200,11 +339,22 @@ func NewFromError[C ErrorCoder](code C, err error) *Err {
 				Message: inner.Error(),
 				Info:    internal.NewInfo(),
 			}
+
+			outer.Info.Inner = inner
+
+			ctx_code, ok := context_code(inner)
+			if ok {
+				outer.Code = ctx_code
+			}
 		}
 	}
 
 	outer.Severity = ERROR
 
+	if len(outer.Info.Callers) == 0 {
+		outer.Info.Callers = capture_callers(1)
+	}
+
 	return outer
 }
 
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	disable_stack_capture(t)
+
+	err := New(OperationFail, `msg "x"`)
+
+	bare := &Err{Severity: WARNING, Code: NoSuchKey, Message: "bare"}
+	no_code := &Err{Severity: FATAL, Message: ""}
+
+	var null *Err
+
+	tests := []struct {
+		name   string
+		format string
+		err    *Err
+		want   string
+	}{
+		{"v", "%v", err, `[ERROR] OperationFail: msg "x"`},
+		{"s", "%s", err, `[ERROR] OperationFail: msg "x"`},
+		{"plus v without stack", "%+v", err, `[ERROR] OperationFail: msg "x"`},
+		{"q", "%q", err, `"msg \"x\""`},
+		{"sharp v", "%#v", bare, `&errors.Err{Severity:errors.SeverityLevel(1), Code:errors.ErrorCode(2), Message:"bare", Info:(*internal.Info)(nil)}`},
+		{"sharp v without code", "%#v", no_code, `&errors.Err{Severity:errors.SeverityLevel(3), Code:nil, Message:"", Info:(*internal.Info)(nil)}`},
+		{"v without message", "%v", no_code, `[FATAL] <nil>: [no message was provided]`},
+		{"unknown verb", "%d", err, `%!d(*errors.Err=[ERROR] OperationFail: msg "x")`},
+		{"nil v", "%v", null, ""},
+		{"nil s", "%s", null, ""},
+		{"nil plus v", "%+v", null, ""},
+		{"nil sharp v", "%#v", null, "(*errors.Err)(nil)"},
+		{"nil q", "%q", null, `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.err); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPlusVStackTrace(t *testing.T) {
+	err := New(OperationFail, "msg")
+
+	got := fmt.Sprintf("%+v", err)
+
+	if !strings.HasPrefix(got, "[ERROR] OperationFail: msg\n") {
+		t.Errorf("Sprintf(%%+v) = %q, want it to start with the short form", got)
+	}
+
+	for _, want := range []string{"Stack trace:", "TestFormatPlusVStackTrace"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Sprintf(%%+v) = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if strings.HasSuffix(got, "\n") {
+		t.Errorf("Sprintf(%%+v) = %q, want no trailing newline", got)
+	}
+
+	if short := fmt.Sprintf("%v", err); strings.Contains(short, "Stack trace:") {
+		t.Errorf("Sprintf(%%v) = %q, want no stack trace", short)
+	}
+}
@@ -0,0 +1,18 @@
+// Command errlint reports misuses of the github.com/PlayerR9/go-errors
+// package.
+//
+// Usage:
+//
+//	errlint [-fix] packages...
+//
+// See the errlint package for the list of checks.
+package main
+
+import (
+	"github.com/PlayerR9/go-errors/errlint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(errlint.Analyzer)
+}
@@ -0,0 +1,426 @@
+package errlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// errors_path is the import path of the errors package.
+const errors_path string = "github.com/PlayerR9/go-errors"
+
+// Analyzer reports misuses of the errors package:
+//   - *Err values created by constructors that are discarded;
+//   - constant frames passed to constructors that do not match the name of
+//     the enclosing function;
+//   - *Err values compared with == or !=, other than against nil;
+//   - constant severity levels outside the defined range.
+//
+// Generated files are not checked.
+var Analyzer *analysis.Analyzer = &analysis.Analyzer{
+	Name:     "errlint",
+	Doc:      "report misuses of the github.com/PlayerR9/go-errors package",
+	URL:      "https://pkg.go.dev/github.com/PlayerR9/go-errors/errlint",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// is_errors_type checks whether the type is the named type of the errors
+// package with the given name.
+//
+// Parameters:
+//   - t: The type to check.
+//   - name: The name of the type.
+//
+// Returns:
+//   - bool: True if the type matches, false otherwise.
+func is_errors_type(t types.Type, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == errors_path && obj.Name() == name
+}
+
+// is_err_ptr checks whether the type is *Err.
+//
+// Parameters:
+//   - t: The type to check.
+//
+// Returns:
+//   - bool: True if the type is *Err, false otherwise.
+func is_err_ptr(t types.Type) bool {
+	ptr, ok := types.Unalias(t).(*types.Pointer)
+	return ok && is_errors_type(ptr.Elem(), "Err")
+}
+
+// run runs the analyzer.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//
+// Returns:
+//   - any: Always nil.
+//   - error: Always nil.
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	min_level, max_level, has_levels := severity_range(pass)
+
+	filter := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	generated := make(map[*ast.File]bool)
+
+	for _, file := range pass.Files {
+		generated[file] = ast.IsGenerated(file)
+	}
+
+	insp.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		if file, ok := stack[0].(*ast.File); ok && generated[file] {
+			return false
+		}
+
+		switch x := n.(type) {
+		case *ast.ExprStmt:
+			check_discarded(pass, x.X)
+		case *ast.AssignStmt:
+			if len(x.Lhs) == len(x.Rhs) {
+				for i, lhs := range x.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && id.Name == "_" {
+						check_discarded(pass, x.Rhs[i])
+					}
+				}
+			}
+		case *ast.BinaryExpr:
+			check_comparison(pass, x)
+		case *ast.CallExpr:
+			check_frame(pass, x, stack)
+		}
+
+		return true
+	})
+
+	if has_levels {
+		for _, file := range pass.Files {
+			if !generated[file] {
+				check_severity(pass, file, min_level, max_level)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// is_frame_constructor checks whether the signature is the one of a
+// constructor that takes a frame.
+//
+// Parameters:
+//   - sig: The signature to check.
+//
+// Returns:
+//   - bool: True if the first parameter is a string named "frame" and the
+//     only result is an *Err, false otherwise.
+func is_frame_constructor(sig *types.Signature) bool {
+	if sig.Params().Len() == 0 || sig.Results().Len() != 1 {
+		return false
+	}
+
+	param := sig.Params().At(0)
+
+	return param.Name() == "frame" && types.Identical(param.Type(), types.Typ[types.String]) && is_err_ptr(sig.Results().At(0).Type())
+}
+
+// is_constructor checks whether the call creates a new *Err.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//   - call: The call expression.
+//
+// Returns:
+//   - bool: True if the callee is a function of the errors package whose
+//     name starts with "New" or "From" and that returns a single *Err, or a
+//     function that takes a frame (see is_frame_constructor). False
+//     otherwise, including for methods and dynamic calls.
+//
+// Other functions that return an *Err, such as test helpers, return it for
+// convenience only and may be called for their side effects.
+func is_constructor(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() != nil {
+		return false
+	}
+
+	if fn.Pkg() != nil && fn.Pkg().Path() == errors_path && (strings.HasPrefix(fn.Name(), "New") || strings.HasPrefix(fn.Name(), "From")) {
+		return sig.Results().Len() == 1 && is_err_ptr(sig.Results().At(0).Type())
+	}
+
+	return is_frame_constructor(sig)
+}
+
+// check_discarded reports calls to constructors whose *Err result is
+// discarded.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//   - expr: The expression whose value is discarded.
+func check_discarded(pass *analysis.Pass, expr ast.Expr) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !is_constructor(pass, call) {
+		return
+	}
+
+	pass.Reportf(call.Pos(), "result of %s is an *Err that is discarded", types.ExprString(call.Fun))
+}
+
+// check_comparison reports comparisons of *Err values with == or !=.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//   - expr: The binary expression.
+func check_comparison(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+
+	is_nil := func(e ast.Expr) bool {
+		tv, ok := pass.TypesInfo.Types[e]
+		return ok && tv.IsNil()
+	}
+
+	if is_nil(expr.X) || is_nil(expr.Y) {
+		return
+	}
+
+	is_err := func(e ast.Expr) bool {
+		t := pass.TypesInfo.TypeOf(e)
+		return t != nil && (is_err_ptr(t) || is_errors_type(t, "Err"))
+	}
+
+	if !is_err(expr.X) && !is_err(expr.Y) {
+		return
+	}
+
+	pass.Reportf(expr.OpPos, "*Err compared with %s; use errors.Is, errors.AsWithCode or the standard errors.Is instead", expr.Op)
+}
+
+// frame_name returns the frame the repository convention expects for the
+// function, e.g. "Foo()" or "Type.Method()".
+//
+// Parameters:
+//   - decl: The function declaration.
+//
+// Returns:
+//   - string: The expected frame.
+func frame_name(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name + "()"
+	}
+
+	recv := decl.Recv.List[0].Type
+
+	for {
+		switch x := recv.(type) {
+		case *ast.StarExpr:
+			recv = x.X
+			continue
+		case *ast.IndexExpr:
+			recv = x.X
+			continue
+		case *ast.IndexListExpr:
+			recv = x.X
+			continue
+		case *ast.ParenExpr:
+			recv = x.X
+			continue
+		}
+
+		break
+	}
+
+	id, ok := recv.(*ast.Ident)
+	if !ok {
+		return decl.Name.Name + "()"
+	}
+
+	return id.Name + "." + decl.Name.Name + "()"
+}
+
+// check_frame reports constant frames passed to constructors that do not
+// match the enclosing function.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//   - call: The call expression.
+//   - stack: The stack of the enclosing nodes, call included.
+//
+// A constructor is any function that returns an *Err and whose first
+// parameter is a string named "frame".
+func check_frame(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	sig, ok := types.Unalias(pass.TypesInfo.TypeOf(call.Fun)).(*types.Signature)
+	if !ok || !is_frame_constructor(sig) {
+		return
+	}
+
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+
+	var decl *ast.FuncDecl
+
+	for i := len(stack) - 1; i >= 0 && decl == nil; i-- {
+		decl, _ = stack[i].(*ast.FuncDecl)
+	}
+
+	if decl == nil {
+		return
+	}
+
+	got := constant.StringVal(tv.Value)
+	want := frame_name(decl)
+
+	if strings.TrimSuffix(got, "()") == strings.TrimSuffix(want, "()") {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     call.Args[0].Pos(),
+		End:     call.Args[0].End(),
+		Message: "frame " + strconv.Quote(got) + " does not match the enclosing function; want " + strconv.Quote(want),
+	}
+
+	if lit, ok := ast.Unparen(call.Args[0]).(*ast.BasicLit); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message: "Replace the frame with " + strconv.Quote(want),
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     lit.Pos(),
+						End:     lit.End(),
+						NewText: []byte(strconv.Quote(want)),
+					},
+				},
+			},
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// severity_range returns the range of the defined severity levels.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//
+// Returns:
+//   - int64: The lowest defined severity level.
+//   - int64: The highest defined severity level.
+//   - bool: True if the errors package is part of the pass, false otherwise.
+func severity_range(pass *analysis.Pass) (int64, int64, bool) {
+	var pkg *types.Package
+
+	if pass.Pkg.Path() == errors_path {
+		pkg = pass.Pkg
+	} else {
+		for _, imp := range pass.Pkg.Imports() {
+			if imp.Path() == errors_path {
+				pkg = imp
+				break
+			}
+		}
+	}
+
+	if pkg == nil {
+		return 0, 0, false
+	}
+
+	var min_level, max_level int64
+
+	found := false
+	scope := pkg.Scope()
+
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !is_errors_type(c.Type(), "SeverityLevel") {
+			continue
+		}
+
+		value, ok := constant.Int64Val(c.Val())
+		if !ok {
+			continue
+		}
+
+		if !found {
+			min_level, max_level = value, value
+			found = true
+		} else {
+			min_level = min(min_level, value)
+			max_level = max(max_level, value)
+		}
+	}
+
+	return min_level, max_level, found
+}
+
+// check_severity reports the constant severity levels of the file that are
+// outside the defined range.
+//
+// Parameters:
+//   - pass: The analysis pass.
+//   - file: The file to check.
+//   - min_level: The lowest defined severity level.
+//   - max_level: The highest defined severity level.
+//
+// Only the outermost constant expression is checked, so that SeverityLevel(7)
+// is reported once.
+func check_severity(pass *analysis.Pass, file *ast.File, min_level, max_level int64) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		expr, ok := node.(ast.Expr)
+		if !ok {
+			return true
+		}
+
+		tv, ok := pass.TypesInfo.Types[expr]
+		if !ok || tv.Value == nil || !is_errors_type(tv.Type, "SeverityLevel") {
+			return true
+		}
+
+		value, ok := constant.Int64Val(tv.Value)
+		if ok && value >= min_level && value <= max_level {
+			return false
+		}
+
+		pass.Reportf(expr.Pos(), "severity level %s is outside the defined range [%d, %d]", tv.Value, min_level, max_level)
+
+		return false
+	})
+}
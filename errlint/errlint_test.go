@@ -0,0 +1,16 @@
+package errlint_test
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/go-errors/errlint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), errlint.Analyzer, "a")
+}
+
+func TestAnalyzerFrameFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), errlint.Analyzer, "frame")
+}
@@ -0,0 +1,55 @@
+package a
+
+import (
+	"github.com/PlayerR9/go-errors"
+)
+
+func discarded() {
+	errors.New(errors.BadParameter, "bad")         // want `result of errors.New is an \*Err that is discarded`
+	_ = errors.New(errors.BadParameter, "bad")     // want `result of errors.New is an \*Err that is discarded`
+	(errors.New(errors.BadParameter, "bad"))       // want `result of errors.New is an \*Err that is discarded`
+	_, _ = errors.New(errors.BadParameter, "a"), 1 // want `result of errors.New is an \*Err that is discarded`
+
+	err := errors.New(errors.BadParameter, "kept")
+	err.ChangeSeverity(errors.WARNING)
+}
+
+func compared(e1, e2 *errors.Err) bool {
+	if e1 == nil || nil != e2 {
+		return false
+	}
+
+	var target errors.Err
+
+	return e1 == e2 || *e1 != target // want `\*Err compared with ==` `\*Err compared with !=`
+}
+
+var sentinel error = errors.New(errors.NoSuchKey, "sentinel")
+
+func foreign(err error) bool {
+	return err == sentinel
+}
+
+func severity() {
+	_ = errors.NewWithSeverity(errors.FATAL, errors.BadParameter, "ok") // want `result of errors.NewWithSeverity`
+	_ = errors.NewWithSeverity(7, errors.BadParameter, "bad")           // want `result of errors.NewWithSeverity` `severity level 7 is outside the defined range \[0, 3\]`
+	_ = errors.SeverityLevel(-1)                                        // want `severity level -1 is outside the defined range \[0, 3\]`
+	_ = errors.FATAL + 1                                                // want `severity level 4 is outside the defined range \[0, 3\]`
+
+	level := errors.INFO
+	_ = level
+}
+
+type T struct{}
+
+func (t *T) Method() *errors.Err {
+	if t == nil {
+		return errors.NewErrNilReceiver("T.Method()")
+	}
+
+	return errors.NewErrNoSuchKey("T.Method", "key")
+}
+
+func Generic[E any]() *errors.Err {
+	return errors.NewErrNilReceiver("Generic()")
+}
@@ -0,0 +1,37 @@
+package a
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/go-errors"
+)
+
+// require returns its *Err for convenience only.
+func require(t testing.TB, err error) *errors.Err {
+	e, _ := err.(*errors.Err)
+	if e == nil {
+		t.Fatalf("not an *Err")
+	}
+
+	return e
+}
+
+func newErrLocal(frame string) *errors.Err {
+	return errors.NewErrNilReceiver(frame)
+}
+
+func notConstructors(t *testing.T, err error, fn func() *errors.Err) {
+	require(t, err)
+	_ = require(t, err)
+
+	e := errors.New(errors.BadParameter, "bad")
+	e.WithMessage("other")
+
+	fn()
+}
+
+func constructors() {
+	errors.FromPanic("boom")            // want `result of errors.FromPanic is an \*Err that is discarded`
+	newErrLocal("constructors()")       // want `result of newErrLocal is an \*Err that is discarded`
+	errors.New[errors.ErrorCode](0, "") // want `result of errors.New\[errors.ErrorCode\] is an \*Err that is discarded`
+}
@@ -0,0 +1,35 @@
+package frame
+
+import (
+	"github.com/PlayerR9/go-errors"
+)
+
+const other = "Other()"
+
+type List[T any] struct {
+	items []T
+}
+
+func Lookup(key string) *errors.Err {
+	return errors.NewErrNoSuchKey("Find()", key) // want `frame "Find\(\)" does not match the enclosing function; want "Lookup\(\)"`
+}
+
+func (l *List[T]) Len() (int, *errors.Err) {
+	if l == nil {
+		return 0, errors.NewErrNilReceiver("List.Size()") // want `frame "List.Size\(\)" does not match the enclosing function; want "List.Len\(\)"`
+	}
+
+	return len(l.items), nil
+}
+
+func Constant() *errors.Err {
+	return errors.NewErrNilReceiver(other) // want `frame "Other\(\)" does not match the enclosing function; want "Constant\(\)"`
+}
+
+func Closure() func() *errors.Err {
+	return func() *errors.Err {
+		return errors.NewErrNilReceiver("Closure") // without parentheses
+	}
+}
+
+var global = errors.NewErrNilReceiver("init()")
@@ -0,0 +1,65 @@
+// Package errors is a stub of the github.com/PlayerR9/go-errors package for
+// the tests of the analyzer.
+package errors
+
+type SeverityLevel int
+
+const (
+	INFO SeverityLevel = iota
+	WARNING
+	ERROR
+	FATAL
+)
+
+type ErrorCode int
+
+const (
+	BadParameter ErrorCode = iota
+	NoSuchKey
+)
+
+func (c ErrorCode) Int() int { return int(c) }
+
+func (c ErrorCode) String() string { return "ErrorCode" }
+
+type ErrorCoder interface {
+	Int() int
+	String() string
+}
+
+type Err struct {
+	Severity SeverityLevel
+	Code     ErrorCoder
+	Message  string
+}
+
+func (e *Err) Error() string { return e.Message }
+
+func New[C ErrorCoder](code C, message string) *Err {
+	return &Err{Severity: ERROR, Code: code, Message: message}
+}
+
+func NewWithSeverity[C ErrorCoder](severity SeverityLevel, code C, message string) *Err {
+	return &Err{Severity: severity, Code: code, Message: message}
+}
+
+func NewErrNilReceiver(frame string) *Err {
+	return New(BadParameter, frame+": receiver must not be nil")
+}
+
+func NewErrNoSuchKey(frame string, key string) *Err {
+	return New(NoSuchKey, frame+": "+key)
+}
+
+func (e *Err) ChangeSeverity(level SeverityLevel) {
+	e.Severity = level
+}
+
+func FromPanic(r any) *Err {
+	return New(BadParameter, "panic")
+}
+
+func (e *Err) WithMessage(message string) *Err {
+	e.Message = message
+	return e
+}
@@ -12,3 +12,69 @@ type ErrorCoder interface {
 
 	fmt.Stringer
 }
+
+// Namespacer is an optional extension of ErrorCoder for codes that belong to
+// a namespace. Namespaced codes are identified as "<namespace>.<name>" (see
+// CodeID) so that codes of different packages cannot be confused.
+type Namespacer interface {
+	// Namespace returns the namespace of the error code.
+	//
+	// Returns:
+	//   - string: The namespace of the error code. Empty if it has none.
+	Namespace() string
+}
+
+// CodeID returns the globally unique identifier of the code.
+//
+// Parameters:
+//   - code: The code.
+//
+// Returns:
+//   - string: The identifier of the code. Empty if code is nil.
+//
+// The identifier is "<namespace>.<name>" if the namespace of the code is
+// known (see CodeNamespace), and "<name>" otherwise.
+func CodeID(code ErrorCoder) string {
+	if code == nil {
+		return ""
+	}
+
+	namespace, ok := CodeNamespace(code)
+	if !ok {
+		return code.String()
+	}
+
+	return namespace + "." + code.String()
+}
+
+// code_label returns the label of the code used by Err.Error() and the
+// other human-readable representations of errors.
+//
+// Parameters:
+//   - code: The code.
+//
+// Returns:
+//   - string: "<namespace>.<name>" if the code implements Namespacer with a
+//     non-empty namespace, the name of the code otherwise. "<nil>" if code is
+//     nil.
+//
+// Unlike CodeID, the namespace a code type is registered under (see
+// RegisterCode) is not part of the label, so that the built-in codes keep
+// their short names.
+func code_label(code ErrorCoder) string {
+	if code == nil {
+		return "<nil>"
+	}
+
+	n, ok := code.(Namespacer)
+	if !ok {
+		return code.String()
+	}
+
+	namespace := n.Namespace()
+	if namespace == "" {
+		return code.String()
+	}
+
+	return namespace + "." + code.String()
+}
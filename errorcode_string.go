@@ -12,11 +12,13 @@ func _() {
 	_ = x[InvalidUsage-1]
 	_ = x[NoSuchKey-2]
 	_ = x[OperationFail-3]
+	_ = x[Canceled-4]
+	_ = x[DeadlineExceeded-5]
 }
 
-const _ErrorCode_name = "BadParameterInvalidUsageNoSuchKeyOperationFail"
+const _ErrorCode_name = "BadParameterInvalidUsageNoSuchKeyOperationFailCanceledDeadlineExceeded"
 
-var _ErrorCode_index = [...]uint8{0, 12, 24, 33, 46}
+var _ErrorCode_index = [...]uint8{0, 12, 24, 33, 46, 54, 70}
 
 func (i ErrorCode) String() string {
 	if i < 0 || i >= ErrorCode(len(_ErrorCode_index)-1) {
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestCodeLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    ErrorCoder
+		want    string
+		want_id string
+	}{
+		{"nil", nil, "<nil>", ""},
+		{"built-in", NoSuchKey, "NoSuchKey", "errors.NoSuchKey"},
+		{"namespacer", UnknownCode{NS: "billing", Name: "Declined", Value: 3}, "billing.Declined", "billing.Declined"},
+		{"empty namespace", UnknownCode{Name: "Declined", Value: 3}, "Declined", "Declined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := code_label(tt.code); got != tt.want {
+				t.Errorf("code_label() = %q, want %q", got, tt.want)
+			}
+
+			if got := CodeID(tt.code); got != tt.want_id {
+				t.Errorf("CodeID() = %q, want %q", got, tt.want_id)
+			}
+		})
+	}
+
+	err := New(UnknownCode{NS: "billing", Name: "Declined"}, "card declined")
+	if got, want := err.Error(), "[ERROR] billing.Declined: card declined"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestParseErrorCode(t *testing.T) {
+	tests := []struct {
+		str      string
+		want     ErrorCode
+		want_err bool
+	}{
+		{"BadParameter", BadParameter, false},
+		{"nosuchkey", NoSuchKey, false},
+		{"DEADLINEEXCEEDED", DeadlineExceeded, false},
+		{" Canceled\t", Canceled, false},
+		{"0", BadParameter, false},
+		{"5", DeadlineExceeded, false},
+		{"6", BadParameter, true},
+		{"-1", BadParameter, true},
+		{"", BadParameter, true},
+		{"NoSuch", BadParameter, true},
+		{"errors.NoSuchKey", BadParameter, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			got, err := ParseErrorCode(tt.str)
+			if (err != nil) != tt.want_err {
+				t.Fatalf("ParseErrorCode() error = %v, want_err %t", err, tt.want_err)
+			}
+
+			if err != nil && !Is(err, BadParameter) {
+				t.Errorf("ParseErrorCode() error = %v, want a BadParameter error", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseErrorCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorCodeText(t *testing.T) {
+	for code := BadParameter; code <= DeadlineExceeded; code++ {
+		t.Run(code.String(), func(t *testing.T) {
+			data, err := code.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() = %v", err)
+			}
+
+			if string(data) != code.String() {
+				t.Errorf("MarshalText() = %q, want %q", data, code.String())
+			}
+
+			var got ErrorCode
+
+			err = got.UnmarshalText(data)
+			if err != nil {
+				t.Fatalf("UnmarshalText() = %v", err)
+			}
+
+			if got != code {
+				t.Errorf("UnmarshalText() = %v, want %v", got, code)
+			}
+		})
+	}
+
+	if _, err := ErrorCode(6).MarshalText(); !Is(err, BadParameter) {
+		t.Errorf("MarshalText() of an out-of-range code = %v, want a BadParameter error", err)
+	}
+
+	var null *ErrorCode
+
+	if err := null.UnmarshalText([]byte("NoSuchKey")); err == nil {
+		t.Errorf("UnmarshalText() on a nil receiver = nil, want an error")
+	}
+}
+
+func TestErrorCodeFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		want     ErrorCode
+		want_err bool
+	}{
+		{nil, OperationFail, false},
+		{[]string{"-code", "nosuchkey"}, NoSuchKey, false},
+		{[]string{"-code=1"}, InvalidUsage, false},
+		{[]string{"-code", "Missing"}, OperationFail, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt_args(tt.args), func(t *testing.T) {
+			code := OperationFail
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&code, "code", "the error code")
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.want_err {
+				t.Fatalf("Parse() error = %v, want_err %t", err, tt.want_err)
+			}
+
+			if code != tt.want {
+				t.Errorf("code = %v, want %v", code, tt.want)
+			}
+
+			if got := fs.Lookup("code").Value.String(); got != tt.want.String() {
+				t.Errorf("String() = %q, want %q", got, tt.want.String())
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"strconv"
+	"strings"
 )
 
 // ErrorCode is the type of the error code.
@@ -24,6 +25,14 @@ const (
 	// OperationFail occurs when an operation cannot be completed
 	// due to an internal error.
 	OperationFail
+
+	// Canceled occurs when an operation is canceled, usually because
+	// its context was canceled.
+	Canceled
+
+	// DeadlineExceeded occurs when an operation does not complete
+	// before its deadline.
+	DeadlineExceeded
 )
 
 // Int implements the error.ErrorCoder interface.
@@ -31,6 +40,80 @@ func (e ErrorCode) Int() int {
 	return int(e)
 }
 
+// IsValid checks whether the error code is one of the defined codes.
+//
+// Returns:
+//   - bool: True if the error code is valid, false otherwise.
+func (e ErrorCode) IsValid() bool {
+	return e >= BadParameter && e <= DeadlineExceeded
+}
+
+// ParseErrorCode parses an error code.
+//
+// Parameters:
+//   - str: The string to parse. Either the name of the error code,
+//     case-insensitively, or its integer value.
+//
+// Returns:
+//   - ErrorCode: The parsed error code.
+//   - error: An error if the string is not a valid error code.
+//
+// Errors:
+//   - *Err with code BadParameter: If the string is not a valid error code.
+func ParseErrorCode(str string) (ErrorCode, error) {
+	trimmed := strings.TrimSpace(str)
+
+	for code := BadParameter; code <= DeadlineExceeded; code++ {
+		if strings.EqualFold(code.String(), trimmed) {
+			return code, nil
+		}
+	}
+
+	value, err := strconv.Atoi(trimmed)
+	if err == nil && ErrorCode(value).IsValid() {
+		return ErrorCode(value), nil
+	}
+
+	p_err := NewErrInvalidParameter("ParseErrorCode()", "error code ("+strconv.Quote(str)+") does not exist")
+	p_err.AddSuggestion("Use one of BadParameter, InvalidUsage, NoSuchKey, OperationFail, Canceled or DeadlineExceeded, or their integer values 0 to 5")
+
+	return BadParameter, p_err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e ErrorCode) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, NewErrInvalidParameter("ErrorCode.MarshalText()", "error code ("+strconv.Itoa(int(e))+") is out of range")
+	}
+
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+//
+// See ParseErrorCode for the accepted formats.
+func (e *ErrorCode) UnmarshalText(text []byte) error {
+	if e == nil {
+		return NewErrNilReceiver("ErrorCode.UnmarshalText()")
+	}
+
+	code, err := ParseErrorCode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*e = code
+
+	return nil
+}
+
+// Set implements the flag.Value interface.
+//
+// See ParseErrorCode for the accepted formats.
+func (e *ErrorCode) Set(str string) error {
+	return e.UnmarshalText([]byte(str))
+}
+
 // NewErrNilReceiver creates a new error.Err error with the code
 // OperationFail.
 //
@@ -75,11 +158,13 @@ func NewErrInvalidParameter(frame, message string) *Err {
 // Returns:
 //   - *error.Err: The new error. Never returns nil.
 func NewErrNilParameter(frame, parameter string) *Err {
-	msg := "parameter (" + strconv.Quote(parameter) + ") must not be nil"
-
-	err := New(BadParameter, msg)
+	err := NewTemplate(BadParameter, "parameter ({parameter:q}) must not be nil", map[string]any{
+		"parameter": parameter,
+	})
 	err.AddSuggestion("Maybe you forgot to initialize the parameter?")
 
+	err.AddFrame(frame)
+
 	return err
 }
 
@@ -111,7 +196,11 @@ func NewErrInvalidUsage(frame, message, usage string) *Err {
 // Returns:
 //   - *error.Err: The new error. Never returns nil.
 func NewErrNoSuchKey(frame, key string) *Err {
-	err := New(NoSuchKey, "key ("+strconv.Quote(key)+") does not exist")
+	err := NewTemplate(NoSuchKey, "key ({key:q}) does not exist", map[string]any{
+		"key": key,
+	})
+
+	err.AddFrame(frame)
 
 	return err
 }
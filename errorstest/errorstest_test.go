@@ -0,0 +1,210 @@
+package errorstest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// fake_tb is a testing.TB that records the failures instead of failing the
+// test. Like the testing package, Fatalf stops the goroutine that calls it.
+type fake_tb struct {
+	testing.TB
+
+	// failures are the messages of the calls to Fatalf.
+	failures []string
+}
+
+// Helper implements the testing.TB interface.
+func (f *fake_tb) Helper() {}
+
+// Fatalf implements the testing.TB interface.
+func (f *fake_tb) Fatalf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+// run calls fn with a fake_tb in a new goroutine and returns the recorded
+// failures.
+func run(fn func(t testing.TB)) []string {
+	tb := &fake_tb{}
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		fn(tb)
+	}()
+
+	<-done
+
+	return tb.failures
+}
+
+// check verifies the recorded failures: none if want is empty, otherwise a
+// single one that contains want.
+func check(t *testing.T, failures []string, want string) {
+	t.Helper()
+
+	if want == "" {
+		if len(failures) != 0 {
+			t.Errorf("unexpected failures: %q", failures)
+		}
+
+		return
+	}
+
+	if len(failures) != 1 {
+		t.Fatalf("failures = %q, want one containing %q", failures, want)
+	}
+
+	if !strings.Contains(failures[0], want) {
+		t.Errorf("failure = %q, want it to contain %q", failures[0], want)
+	}
+}
+
+func TestRequireHelpers(t *testing.T) {
+	err := errors.NewWithSeverity(errors.WARNING, errors.NoSuchKey, "missing")
+	err.AddContext("key", "foo")
+	err.AddSuggestion("Check the spelling of the key")
+
+	wrapped := fmt.Errorf("lookup: %w", err)
+
+	tests := []struct {
+		name string
+		fn   func(t testing.TB)
+		want string
+	}{
+		{"code", func(t testing.TB) { RequireCode(t, wrapped, errors.NoSuchKey) }, ""},
+		{"code mismatch", func(t testing.TB) { RequireCode(t, wrapped, errors.BadParameter) }, "codes in chain: errors.NoSuchKey"},
+		{"code nil", func(t testing.TB) { RequireCode(t, nil, errors.NoSuchKey) }, "got nil"},
+		{"code foreign", func(t testing.TB) { RequireCode(t, fmt.Errorf("plain"), errors.NoSuchKey) }, "no *errors.Err in chain"},
+		{"severity", func(t testing.TB) { RequireSeverity(t, wrapped, errors.WARNING) }, ""},
+		{"severity mismatch", func(t testing.TB) { RequireSeverity(t, wrapped, errors.FATAL) }, "severity mismatch"},
+		{"severity foreign", func(t testing.TB) { RequireSeverity(t, fmt.Errorf("plain"), errors.ERROR) }, "no *errors.Err in chain"},
+		{"context", func(t testing.TB) { RequireContext(t, wrapped, "key", "foo") }, ""},
+		{"context mismatch", func(t testing.TB) { RequireContext(t, wrapped, "key", "bar") }, `context value mismatch for key "key"`},
+		{"context missing", func(t testing.TB) { RequireContext(t, wrapped, "other", "foo") }, `expected context key "other"`},
+		{"cause", func(t testing.TB) { RequireCause(t, wrapped, err) }, ""},
+		{"cause missing", func(t testing.TB) { RequireCause(t, wrapped, fmt.Errorf("other")) }, `expected cause "other"`},
+		{"suggestion", func(t testing.TB) { RequireSuggestionContains(t, wrapped, "spelling") }, ""},
+		{"suggestion mismatch", func(t testing.TB) { RequireSuggestionContains(t, wrapped, "retry") }, "Check the spelling of the key"},
+		{"no suggestions", func(t testing.TB) { RequireSuggestionContains(t, fmt.Errorf("plain"), "retry") }, "has no suggestions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, run(tt.fn), tt.want)
+		})
+	}
+}
+
+func TestRequireCodeReturnsErr(t *testing.T) {
+	inner := errors.New(errors.NoSuchKey, "inner")
+	outer := errors.NewWithSeverity(errors.FATAL, errors.OperationFail, "outer")
+	outer.SetInner(inner)
+
+	var got *errors.Err
+
+	check(t, run(func(t testing.TB) {
+		got = RequireCode(t, outer, errors.NoSuchKey)
+	}), "")
+
+	if got != inner {
+		t.Errorf("RequireCode() = %v, want %v", got, inner)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{"equal", "a\nb", "a\nb", "  a\n  b\n"},
+		{"changed", "a\nb\nc", "a\nx\nc", "  a\n- b\n+ x\n  c\n"},
+		{"added", "a\nc", "a\nb\nc", "  a\n+ b\n  c\n"},
+		{"removed", "a\nb\nc", "a\nc", "  a\n- b\n  c\n"},
+		{"empty want", "", "a", "- \n+ a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := line_diff(tt.want, tt.got); diff != tt.diff {
+				t.Errorf("line_diff() =\n%q\nwant\n%q", diff, tt.diff)
+			}
+		})
+	}
+}
+
+// set_update sets the update flag for the duration of the test.
+func set_update(t *testing.T, value bool) {
+	t.Helper()
+
+	old := *update
+	*update = value
+
+	t.Cleanup(func() {
+		*update = old
+	})
+}
+
+func TestRequireGolden(t *testing.T) {
+	old := errors.DefaultRenderer()
+	errors.SetDefaultRenderer(errors.TextRenderer{Verbose: true})
+	t.Cleanup(func() { errors.SetDefaultRenderer(old) })
+
+	t.Setenv(UpdateEnv, "")
+
+	err := errors.New(errors.BadParameter, "bad")
+	path := filepath.Join(t.TempDir(), "testdata", "bad.golden")
+
+	check(t, run(func(t testing.TB) { RequireGolden(t, err, path) }), "failed to read golden file")
+
+	set_update(t, true)
+
+	check(t, run(func(t testing.TB) { RequireGolden(t, err, path) }), "")
+
+	data, r_err := os.ReadFile(path)
+	if r_err != nil {
+		t.Fatalf("golden file was not written: %v", r_err)
+	}
+
+	if want := "[ERROR] BadParameter: bad\n"; string(data) != want {
+		t.Errorf("golden file = %q, want %q", data, want)
+	}
+
+	*update = false
+
+	check(t, run(func(t testing.TB) { RequireGolden(t, err, path) }), "")
+
+	other := errors.New(errors.BadParameter, "worse")
+
+	check(t, run(func(t testing.TB) { RequireGolden(t, other, path) }), "+ [ERROR] BadParameter: worse")
+}
+
+func TestRequireGoldenUpdateEnv(t *testing.T) {
+	set_update(t, false)
+
+	path := filepath.Join(t.TempDir(), "env.golden")
+	err := errors.New(errors.BadParameter, "bad")
+
+	t.Setenv(UpdateEnv, "1")
+
+	check(t, run(func(t testing.TB) { RequireGolden(t, err, path) }), "")
+
+	if _, s_err := os.Stat(path); s_err != nil {
+		t.Fatalf("golden file was not written: %v", s_err)
+	}
+
+	t.Setenv(UpdateEnv, "0")
+
+	os.Remove(path)
+
+	check(t, run(func(t testing.TB) { RequireGolden(t, err, path) }), "failed to read golden file")
+}
@@ -0,0 +1,140 @@
+package errorstest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// update is true if golden files must be rewritten instead of compared. The
+// flag is namespaced so that it does not conflict with the -update flags of
+// other packages.
+var update = flag.Bool("errorstest.update", false, "rewrite the golden files of errorstest.RequireGolden")
+
+// UpdateEnv is the environment variable that, when set to a true value (see
+// strconv.ParseBool), rewrites the golden files like -errorstest.update.
+const UpdateEnv string = "ERRORSTEST_UPDATE"
+
+// should_update checks whether golden files must be rewritten.
+//
+// Returns:
+//   - bool: True if the -errorstest.update flag is set or UpdateEnv is true.
+func should_update() bool {
+	if *update {
+		return true
+	}
+
+	ok, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return ok
+}
+
+// golden_renderer is the renderer of the golden files.
+var golden_renderer errors.Renderer = errors.TextRenderer{Color: errors.ColorNever}
+
+// RequireGolden fails the test immediately unless the rendering of err
+// matches the content of the golden file.
+//
+// Parameters:
+//   - t: The test.
+//   - err: The error to display.
+//   - path: The path of the golden file, usually under testdata.
+//
+// The error is rendered by an errors.TextRenderer without colours or stack
+// traces so that the golden files do not depend on the terminal, the default
+// renderer or the layout of the source files. Run the tests with
+// -errorstest.update (or with UpdateEnv set to 1) to create or rewrite the
+// golden files.
+func RequireGolden(t testing.TB, err error, path string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	d_err := errors.DisplayErrorWith(&buf, err, golden_renderer)
+	if d_err != nil {
+		t.Fatalf("failed to display error: %v", d_err)
+	}
+
+	got := buf.String()
+
+	if should_update() {
+		m_err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if m_err == nil {
+			m_err = os.WriteFile(path, []byte(got), 0o644)
+		}
+
+		if m_err != nil {
+			t.Fatalf("failed to update golden file: %v", m_err)
+		}
+
+		return
+	}
+
+	data, r_err := os.ReadFile(path)
+	if r_err != nil {
+		t.Fatalf("failed to read golden file (run with -errorstest.update or "+UpdateEnv+"=1 to create it): %v", r_err)
+	}
+
+	want := string(data)
+
+	if got != want {
+		t.Fatalf("output does not match golden file %s (-want +got):\n%s", path, line_diff(want, got))
+	}
+}
+
+// line_diff returns a line-based diff of two texts. Lines only in want are
+// prefixed with "-", lines only in got with "+" and common lines with " ".
+//
+// Parameters:
+//   - want: The expected text.
+//   - got: The actual text.
+//
+// Returns:
+//   - string: The diff.
+func line_diff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var builder strings.Builder
+
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			builder.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			builder.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			builder.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+
+	return builder.String()
+}
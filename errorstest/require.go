@@ -0,0 +1,184 @@
+package errorstest
+
+import (
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// chain returns the *errors.Err values of the chain of err, outermost first.
+//
+// Parameters:
+//   - err: The error to walk.
+//
+// Returns:
+//   - []*errors.Err: The errors of the chain. Nil if there are none.
+func chain(err error) []*errors.Err {
+	var errs []*errors.Err
+
+	for err != nil {
+		if e, ok := err.(*errors.Err); ok && e != nil {
+			errs = append(errs, e)
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, sub := range x.Unwrap() {
+				errs = append(errs, chain(sub)...)
+			}
+
+			return errs
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		default:
+			return errs
+		}
+	}
+
+	return errs
+}
+
+// describe returns the codes of the chain of err for failure messages.
+//
+// Parameters:
+//   - err: The error to describe.
+//
+// Returns:
+//   - string: The identifiers of the codes of the chain, in order.
+func describe(err error) string {
+	errs := chain(err)
+	if len(errs) == 0 {
+		return "no *errors.Err in chain"
+	}
+
+	ids := make([]string, 0, len(errs))
+
+	for _, e := range errs {
+		if e.Code == nil {
+			ids = append(ids, "<nil>")
+		} else {
+			ids = append(ids, errors.CodeID(e.Code))
+		}
+	}
+
+	return "codes in chain: " + strings.Join(ids, ", ")
+}
+
+// RequireCode fails the test immediately unless the chain of err contains
+// an *errors.Err with the given code.
+//
+// Parameters:
+//   - t: The test.
+//   - err: The error to check.
+//   - code: The expected code.
+//
+// Returns:
+//   - *errors.Err: The first error of the chain with the code.
+func RequireCode[T errors.ErrorCoder](t testing.TB, err error, code T) *errors.Err {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("expected error with code %s, got nil", errors.CodeID(code))
+	}
+
+	e, ok := errors.AsWithCode(err, code)
+	if !ok {
+		t.Fatalf("expected error with code %s, got %q (%s)", errors.CodeID(code), err.Error(), describe(err))
+	}
+
+	return e
+}
+
+// RequireSeverity fails the test immediately unless the outermost
+// *errors.Err of the chain of err has the given severity level.
+//
+// Parameters:
+//   - t: The test.
+//   - err: The error to check.
+//   - level: The expected severity level.
+func RequireSeverity(t testing.TB, err error, level errors.SeverityLevel) {
+	t.Helper()
+
+	e, ok := errors.As(err)
+	if !ok {
+		t.Fatalf("expected error with severity %s, got %v (no *errors.Err in chain)", level, err)
+	}
+
+	if e.Severity != level {
+		t.Fatalf("severity mismatch for %q:\n\twant: %s\n\tgot:  %s", e.Error(), level, e.Severity)
+	}
+}
+
+// RequireContext fails the test immediately unless the first *errors.Err of
+// the chain of err that has the key in its context maps it to want.
+//
+// Parameters:
+//   - t: The test.
+//   - err: The error to check.
+//   - key: The key of the context.
+//   - want: The expected value, compared with reflect.DeepEqual.
+func RequireContext(t testing.TB, err error, key string, want any) {
+	t.Helper()
+
+	for _, e := range chain(err) {
+		got, ok := e.Value(key)
+		if !ok {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("context value mismatch for key %q:\n\twant: %#v\n\tgot:  %#v", key, want, got)
+		}
+
+		return
+	}
+
+	t.Fatalf("expected context key %q, not found in %v (%s)", key, err, describe(err))
+}
+
+// RequireCause fails the test immediately unless target is part of the
+// chain of err, as reported by the standard errors.Is.
+//
+// Parameters:
+//   - t: The test.
+//   - err: The error to check.
+//   - target: The expected cause.
+func RequireCause(t testing.TB, err, target error) {
+	t.Helper()
+
+	if !stderrors.Is(err, target) {
+		t.Fatalf("expected cause %q in chain of %v", target, err)
+	}
+}
+
+// RequireSuggestionContains fails the test immediately unless an
+// *errors.Err of the chain of err has a suggestion that contains substr.
+//
+// Parameters:
+//   - t: The test.
+//   - err: The error to check.
+//   - substr: The expected substring.
+func RequireSuggestionContains(t testing.TB, err error, substr string) {
+	t.Helper()
+
+	var all []string
+
+	for _, e := range chain(err) {
+		for _, suggestion := range e.Suggestions() {
+			if strings.Contains(suggestion, substr) {
+				return
+			}
+
+			all = append(all, suggestion)
+		}
+	}
+
+	if len(all) == 0 {
+		t.Fatalf("expected a suggestion containing %q, %v has no suggestions", substr, err)
+	}
+
+	t.Fatalf("expected a suggestion containing %q, got:\n\t%s", substr, strings.Join(all, "\n\t"))
+}
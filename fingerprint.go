@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Fingerprint returns a stable identifier of the error, suitable to group
+// the occurrences of a same error.
+//
+// Returns:
+//   - string: The fingerprint, as 16 hexadecimal characters. Empty if the
+//     receiver is nil.
+//
+// The fingerprint is computed from the identifier of the code (see CodeID),
+// the manually added frames and the functions of the captured stack trace.
+// Messages, severity levels and line numbers are not part of it so that it
+// stays stable across values, policies (see Policy.Apply) and edits.
+func (e *Err) Fingerprint() string {
+	if e == nil {
+		return ""
+	}
+
+	h := sha256.New()
+
+	h.Write([]byte(CodeID(e.Code)))
+
+	if e.Info != nil {
+		for _, frame := range e.Info.Frames {
+			h.Write([]byte{0})
+			h.Write([]byte(frame))
+		}
+
+		for _, frame := range resolve_frames(e.Info.Callers) {
+			h.Write([]byte{0})
+			h.Write([]byte(frame.Function))
+		}
+	}
+
+	sum := h.Sum(nil)
+
+	return hex.EncodeToString(sum[:8])
+}
@@ -2,3 +2,4 @@ package errors
 
 //go:generate stringer -type=ErrorCode
 //go:generate stringer -type=SeverityLevel
+//go:generate stringer -type=Retryability -trimprefix=Retry
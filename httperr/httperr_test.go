@@ -0,0 +1,280 @@
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// custom_code is a code type that is not part of the default mapping.
+type custom_code int
+
+func (c custom_code) Int() int       { return int(c) }
+func (c custom_code) String() string { return "custom" }
+
+// status_code is a code type that declares its HTTP status code.
+type status_code int
+
+func (c status_code) Int() int        { return int(c) }
+func (c status_code) String() string  { return "status" }
+func (c status_code) HTTPStatus() int { return int(c) }
+
+func TestStatusTable(t *testing.T) {
+	st := NewStatusTable()
+	st.Set(custom_code(1), http.StatusTeapot)
+	st.Set(status_code(http.StatusGone), http.StatusTeapot)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"bad parameter", errors.New(errors.BadParameter, "bad"), http.StatusBadRequest},
+		{"invalid usage", errors.New(errors.InvalidUsage, "bad"), http.StatusBadRequest},
+		{"no such key", errors.New(errors.NoSuchKey, "missing"), http.StatusNotFound},
+		{"operation fail", errors.New(errors.OperationFail, "fail"), http.StatusInternalServerError},
+		{"deadline exceeded", errors.New(errors.DeadlineExceeded, "slow"), http.StatusGatewayTimeout},
+		{"custom", errors.New(custom_code(1), "tea"), http.StatusTeapot},
+		{"unmapped custom", errors.New(custom_code(2), "tea"), http.StatusInternalServerError},
+		{"declared status", errors.New(status_code(http.StatusConflict), "conflict"), http.StatusConflict},
+		{"declared status overridden", errors.New(status_code(http.StatusGone), "gone"), http.StatusTeapot},
+		{"unspecified declared status", errors.New(status_code(0), "none"), http.StatusInternalServerError},
+		{"invalid declared status", errors.New(status_code(1000), "bad"), http.StatusInternalServerError},
+		{"foreign", fmt.Errorf("plain"), http.StatusInternalServerError},
+		{"wrapped", fmt.Errorf("wrap: %w", errors.New(errors.NoSuchKey, "missing")), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := st.StatusOf(tt.err)
+			if got != tt.want {
+				t.Errorf("StatusOf() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	st.SetFallback(http.StatusBadGateway)
+
+	got := st.StatusOf(fmt.Errorf("plain"))
+	if got != http.StatusBadGateway {
+		t.Errorf("StatusOf() after SetFallback = %d, want %d", got, http.StatusBadGateway)
+	}
+}
+
+// serve runs the handler against a new request and decodes the response.
+func serve(t *testing.T, h http.Handler) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42?x=1", nil)
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Fatalf("Content-Type = %q, want %q", ct, ContentType)
+	}
+
+	var doc map[string]any
+
+	err := json.Unmarshal(rec.Body.Bytes(), &doc)
+	if err != nil {
+		t.Fatalf("invalid JSON body %q: %v", rec.Body.String(), err)
+	}
+
+	return rec, doc
+}
+
+func TestHandlerProblem(t *testing.T) {
+	h := Handler(nil, func(w http.ResponseWriter, r *http.Request) error {
+		err := errors.New(errors.NoSuchKey, "item 42 does not exist")
+		err.AddSuggestion("Check the identifier")
+		err.AddContext("id", 42)
+
+		return err
+	})
+
+	rec, doc := serve(t, h)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	want := map[string]any{
+		"type":        "about:blank",
+		"title":       "Not Found",
+		"status":      float64(http.StatusNotFound),
+		"detail":      "item 42 does not exist",
+		"instance":    "/items/42?x=1",
+		"code":        "errors.NoSuchKey",
+		"severity":    "ERROR",
+		"suggestions": []any{"Check the identifier"},
+		"id":          float64(42),
+	}
+
+	for key, value := range want {
+		got, ok := doc[key]
+		if !ok {
+			t.Errorf("member %q is missing", key)
+		} else if fmt.Sprint(got) != fmt.Sprint(value) {
+			t.Errorf("member %q = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestHandlerForeignError(t *testing.T) {
+	h := Handler(nil, func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("disk on fire")
+	})
+
+	rec, doc := serve(t, h)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	if doc["detail"] != "Internal Server Error" {
+		t.Errorf("detail = %v, want %q", doc["detail"], "Internal Server Error")
+	}
+}
+
+func TestServerErrorDetails(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) error {
+		err := errors.New(errors.OperationFail, "database password rejected")
+		err.AddSuggestion("Rotate the credentials")
+		err.AddContext("dsn", "postgres://admin@db")
+
+		return err
+	}
+
+	tests := []struct {
+		name   string
+		expose bool
+
+		// want_detail is the expected detail member.
+		want_detail string
+
+		// want_members are the members that must be present.
+		want_members []string
+
+		// hidden_members are the members that must be absent.
+		hidden_members []string
+	}{
+		{
+			name:           "hidden by default",
+			want_detail:    "Internal Server Error",
+			want_members:   []string{"title", "status", "code", "severity"},
+			hidden_members: []string{"suggestions", "dsn"},
+		},
+		{
+			name:         "exposed",
+			expose:       true,
+			want_detail:  "database password rejected",
+			want_members: []string{"title", "status", "code", "severity", "suggestions", "dsn"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := NewStatusTable()
+			st.SetExposeDetails(tt.expose)
+
+			_, doc := serve(t, Handler(st, fn))
+
+			if doc["title"] != "Internal Server Error" {
+				t.Errorf("title = %v, want %q", doc["title"], "Internal Server Error")
+			}
+
+			if doc["detail"] != tt.want_detail {
+				t.Errorf("detail = %v, want %q", doc["detail"], tt.want_detail)
+			}
+
+			for _, key := range tt.want_members {
+				if _, ok := doc[key]; !ok {
+					t.Errorf("member %q is missing", key)
+				}
+			}
+
+			for _, key := range tt.hidden_members {
+				if _, ok := doc[key]; ok {
+					t.Errorf("member %q = %v, want it hidden", key, doc[key])
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerUnencodableContext(t *testing.T) {
+	h := Handler(nil, func(w http.ResponseWriter, r *http.Request) error {
+		err := errors.New(errors.BadParameter, "bad input")
+		err.AddContext("callback", func() {})
+
+		return err
+	})
+
+	rec, doc := serve(t, h)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	if doc["detail"] != "bad input" {
+		t.Errorf("detail = %v, want %q", doc["detail"], "bad input")
+	}
+
+	if _, ok := doc["callback"]; ok {
+		t.Errorf("unencodable extension member was written")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	h := Recover(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec, doc := serve(t, h)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	if doc["severity"] != "FATAL" {
+		t.Errorf("severity = %v, want FATAL", doc["severity"])
+	}
+
+	if doc["detail"] != "Internal Server Error" {
+		t.Errorf("detail = %v, want %q", doc["detail"], "Internal Server Error")
+	}
+
+	st := NewStatusTable()
+	st.SetExposeDetails(true)
+
+	_, doc = serve(t, Recover(st, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	if doc["detail"] != "panic: boom" {
+		t.Errorf("exposed detail = %v, want %q", doc["detail"], "panic: boom")
+	}
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	h := Recover(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		r := recover()
+		if r != http.ErrAbortHandler {
+			t.Errorf("recover() = %v, want http.ErrAbortHandler", r)
+		}
+	}()
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	t.Errorf("http.ErrAbortHandler was not re-panicked")
+}
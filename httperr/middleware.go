@@ -0,0 +1,100 @@
+package httperr
+
+import (
+	"log"
+	"net/http"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// HandlerFunc is an HTTP handler that can return an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Handler converts a HandlerFunc into an http.Handler that writes any
+// returned error as a problem document.
+//
+// Parameters:
+//   - st: The status table to use. If nil, the default mapping is used.
+//   - fn: The handler function.
+//
+// Returns:
+//   - http.Handler: The handler. Never returns nil.
+//
+// Panics are recovered as well (see Recover).
+func Handler(st *StatusTable, fn HandlerFunc) http.Handler {
+	var h http.Handler
+
+	if fn == nil {
+		h = http.NotFoundHandler()
+	} else {
+		h = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := fn(w, r)
+			if err != nil {
+				write_problem(w, r, st, err)
+			}
+		})
+	}
+
+	return Recover(st, h)
+}
+
+// write_problem is like WriteProblem but logs the errors that occur while
+// writing the problem to the error log of the server, or to the standard
+// logger if there is none.
+//
+// Parameters:
+//   - w: The response writer.
+//   - r: The request. Assumed to be non-nil.
+//   - st: The status table to use. If nil, the default mapping is used.
+//   - err: The error to write.
+func write_problem(w http.ResponseWriter, r *http.Request, st *StatusTable, err error) {
+	w_err := WriteProblem(w, r, st, err)
+	if w_err == nil {
+		return
+	}
+
+	srv, _ := r.Context().Value(http.ServerContextKey).(*http.Server)
+	if srv != nil && srv.ErrorLog != nil {
+		srv.ErrorLog.Printf("httperr: failed to write problem: %v", w_err)
+	} else {
+		log.Printf("httperr: failed to write problem: %v", w_err)
+	}
+}
+
+// Recover is a middleware that recovers panics raised by next and writes
+// them as problem documents.
+//
+// Parameters:
+//   - st: The status table to use. If nil, the default mapping is used.
+//   - next: The handler to wrap.
+//
+// Returns:
+//   - http.Handler: The handler. Never returns nil.
+//
+// The recovered value is converted into a FATAL *errors.Err with code
+// OperationFail. Its message, which holds the recovered value, is only sent
+// to the client if st exposes the details of server errors (see
+// StatusTable.SetExposeDetails). http.ErrAbortHandler is re-panicked as
+// required by net/http.
+func Recover(st *StatusTable, next http.Handler) http.Handler {
+	if next == nil {
+		next = http.NotFoundHandler()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			r_val := recover()
+			if r_val == nil {
+				return
+			}
+
+			if r_val == http.ErrAbortHandler {
+				panic(r_val)
+			}
+
+			write_problem(w, r, st, errors.FromPanic(r_val))
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
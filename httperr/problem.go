@@ -0,0 +1,214 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errors "github.com/PlayerR9/go-errors"
+)
+
+// ContentType is the media type of problem documents.
+const ContentType string = "application/problem+json"
+
+// Problem is a problem details document as defined by RFC 9457.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type.
+	Type string
+
+	// Title is a short, human-readable summary of the problem type.
+	Title string
+
+	// Status is the HTTP status code.
+	Status int
+
+	// Detail is a human-readable explanation of this occurrence.
+	Detail string
+
+	// Instance is a URI reference that identifies this occurrence.
+	Instance string
+
+	// Extensions are additional members of the document.
+	Extensions map[string]any
+}
+
+// reserved_members are the members defined by RFC 9457 that extensions
+// cannot override.
+var reserved_members = map[string]struct{}{
+	"type":     {},
+	"title":    {},
+	"status":   {},
+	"detail":   {},
+	"instance": {},
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// Extensions are written as top-level members. Extensions whose name
+// collides with a standard member are ignored.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	doc := make(map[string]any, len(p.Extensions)+5)
+
+	for key, value := range p.Extensions {
+		if _, ok := reserved_members[key]; !ok {
+			doc[key] = value
+		}
+	}
+
+	if p.Type == "" {
+		doc["type"] = "about:blank"
+	} else {
+		doc["type"] = p.Type
+	}
+
+	if p.Title != "" {
+		doc["title"] = p.Title
+	}
+
+	if p.Status != 0 {
+		doc["status"] = p.Status
+	}
+
+	if p.Detail != "" {
+		doc["detail"] = p.Detail
+	}
+
+	if p.Instance != "" {
+		doc["instance"] = p.Instance
+	}
+
+	return json.Marshal(doc)
+}
+
+// NewProblem creates a problem document from an error.
+//
+// Parameters:
+//   - st: The status table to use. If nil, the default mapping is used.
+//   - err: The error to convert.
+//
+// Returns:
+//   - *Problem: A pointer to the new problem. Nil if err is nil.
+//
+// The title is the reason phrase of the status, as RFC 9457 requires for
+// the "about:blank" type, and the detail is the message of the error. The
+// identifier of the code (see errors.CodeID) and the severity level are
+// added as the "code" and "severity" extension members, and the suggestions
+// and context of the error as the "suggestions" member and top-level
+// members, respectively.
+//
+// For server error statuses (5xx), the detail is the reason phrase of the
+// status and the suggestions and context are omitted, unless the table
+// exposes them (see StatusTable.SetExposeDetails).
+func NewProblem(st *StatusTable, err error) *Problem {
+	if err == nil {
+		return nil
+	}
+
+	if st == nil {
+		st = default_table
+	}
+
+	status := st.StatusOf(err)
+	expose := st.ExposesDetails(status)
+
+	p := &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: http.StatusText(status),
+	}
+
+	e, ok := errors.As(err)
+	if !ok {
+		if expose {
+			p.Detail = err.Error()
+		}
+
+		return p
+	}
+
+	p.Extensions = make(map[string]any)
+
+	if e.Code != nil {
+		p.Extensions["code"] = errors.CodeID(e.Code)
+	}
+
+	p.Extensions["severity"] = e.Severity.String()
+
+	if !expose {
+		return p
+	}
+
+	p.Detail = e.RenderMessage()
+
+	suggestions := e.Suggestions()
+	if len(suggestions) > 0 {
+		p.Extensions["suggestions"] = suggestions
+	}
+
+	if e.Info != nil {
+		for key, value := range e.Info.Context {
+			if _, ok := p.Extensions[key]; !ok {
+				p.Extensions[key] = value
+			}
+		}
+	}
+
+	return p
+}
+
+// WriteProblem writes the error as a problem document. Does nothing if
+// err is nil.
+//
+// Parameters:
+//   - w: The response writer.
+//   - r: The request. Its URL is used as the instance of the problem. May
+//     be nil.
+//   - st: The status table to use. If nil, the default mapping is used.
+//   - err: The error to write.
+//
+// Returns:
+//   - error: The error that occurred while encoding or writing the response.
+//
+// If the problem cannot be encoded (e.g., a context value is not supported
+// by encoding/json), a minimal problem without the extension members is
+// written instead and the encoding error is returned.
+func WriteProblem(w http.ResponseWriter, r *http.Request, st *StatusTable, err error) error {
+	if err == nil {
+		return nil
+	} else if w == nil {
+		return errors.NewErrNilParameter("WriteProblem()", "w")
+	}
+
+	p := NewProblem(st, err)
+
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.RequestURI()
+	}
+
+	data, m_err := json.Marshal(p)
+	if m_err != nil {
+		minimal := &Problem{
+			Type:     p.Type,
+			Title:    p.Title,
+			Status:   p.Status,
+			Detail:   p.Detail,
+			Instance: p.Instance,
+		}
+
+		// Cannot fail: the standard members are strings and an integer.
+		data, _ = json.Marshal(minimal)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+
+	_, w_err := w.Write(data)
+	if m_err != nil {
+		return m_err
+	}
+
+	return w_err
+}
@@ -0,0 +1,205 @@
+package httperr
+
+import (
+	"net/http"
+	"sync"
+
+	errors "github.com/PlayerR9/go-errors"
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// StatusClientClosedRequest is the non-standard status code used by nginx
+// when the client closes the connection before the response is sent.
+const StatusClientClosedRequest int = 499
+
+// default_table is the status table used when none is provided.
+var default_table *StatusTable = NewStatusTable()
+
+// StatusTable maps error codes to HTTP status codes. It is safe for
+// concurrent use.
+type StatusTable struct {
+	// mu protects the table.
+	mu sync.RWMutex
+
+	// table is the mapping of codes to status codes.
+	table internal.CodeMap[int]
+
+	// fallback is the status code used for unmapped codes.
+	fallback int
+
+	// expose_details is true if the messages and context of the errors with
+	// a server error status are written to the clients.
+	expose_details bool
+}
+
+// NewStatusTable creates a new StatusTable with the default mapping.
+//
+// Returns:
+//   - *StatusTable: A pointer to the new StatusTable. Never returns nil.
+//
+// Default mapping:
+//   - errors.BadParameter: 400 Bad Request
+//   - errors.InvalidUsage: 400 Bad Request
+//   - errors.NoSuchKey: 404 Not Found
+//   - errors.OperationFail: 500 Internal Server Error
+//   - errors.Canceled: 499 Client Closed Request (nginx convention)
+//   - errors.DeadlineExceeded: 504 Gateway Timeout
+//   - Any other code: 500 Internal Server Error
+func NewStatusTable() *StatusTable {
+	st := &StatusTable{
+		fallback: http.StatusInternalServerError,
+	}
+
+	st.Set(errors.BadParameter, http.StatusBadRequest)
+	st.Set(errors.InvalidUsage, http.StatusBadRequest)
+	st.Set(errors.NoSuchKey, http.StatusNotFound)
+	st.Set(errors.OperationFail, http.StatusInternalServerError)
+	st.Set(errors.Canceled, StatusClientClosedRequest)
+	st.Set(errors.DeadlineExceeded, http.StatusGatewayTimeout)
+
+	return st
+}
+
+// Set maps the code to the given status code. Does nothing if the
+// receiver or the code is nil.
+//
+// Parameters:
+//   - code: The code to map.
+//   - status: The HTTP status code.
+func (st *StatusTable) Set(code errors.ErrorCoder, status int) {
+	if st == nil || code == nil {
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.table.Set(code, status)
+}
+
+// SetFallback sets the status code used for codes that are not mapped.
+// Does nothing if the receiver is nil.
+//
+// Parameters:
+//   - status: The HTTP status code.
+func (st *StatusTable) SetFallback(status int) {
+	if st == nil {
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.fallback = status
+}
+
+// SetExposeDetails sets whether the problems of the errors with a server
+// error status (5xx) include the message, suggestions and context of the
+// error. Does nothing if the receiver is nil.
+//
+// Parameters:
+//   - expose: True to expose the details, false to hide them (default).
+//
+// Hidden details keep internal messages, such as the values of recovered
+// panics, from reaching the clients.
+func (st *StatusTable) SetExposeDetails(expose bool) {
+	if st == nil {
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.expose_details = expose
+}
+
+// ExposesDetails checks whether the details of the errors with the given
+// status are written to the clients (see SetExposeDetails).
+//
+// Parameters:
+//   - status: The HTTP status code.
+//
+// Returns:
+//   - bool: True if status is not a server error status or the details are
+//     exposed, false otherwise (including if the receiver is nil).
+func (st *StatusTable) ExposesDetails(status int) bool {
+	if status < 500 {
+		return true
+	} else if st == nil {
+		return false
+	}
+
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	return st.expose_details
+}
+
+// HTTPStatuser is an optional extension of errors.ErrorCoder for codes that
+// declare their HTTP status code, such as the codes generated by errgen.
+type HTTPStatuser interface {
+	// HTTPStatus returns the HTTP status code of the code.
+	//
+	// Returns:
+	//   - int: The HTTP status code. 0 if unspecified.
+	HTTPStatus() int
+}
+
+// Status returns the HTTP status code of the given code.
+//
+// Parameters:
+//   - code: The code to look up.
+//
+// Returns:
+//   - int: The HTTP status code. 500 if the receiver is nil.
+//
+// The entries of the table take precedence. Codes without an entry use the
+// status code they declare (see HTTPStatuser), if it is valid, and the
+// fallback status code otherwise.
+func (st *StatusTable) Status(code errors.ErrorCoder) int {
+	if st == nil {
+		return http.StatusInternalServerError
+	}
+
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	if code == nil {
+		return st.fallback
+	}
+
+	status, ok := st.table.Get(code)
+	if ok {
+		return status
+	}
+
+	if s, ok := code.(HTTPStatuser); ok {
+		status = s.HTTPStatus()
+		if status >= 100 && status <= 599 {
+			return status
+		}
+	}
+
+	return st.fallback
+}
+
+// StatusOf returns the HTTP status code of the given error.
+//
+// Parameters:
+//   - err: The error to look up.
+//
+// Returns:
+//   - int: The HTTP status code of the outermost *errors.Err of the chain,
+//     or the fallback status code if there is none. 200 if err is nil.
+func (st *StatusTable) StatusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	e, ok := errors.As(err)
+	if !ok {
+		return st.Status(nil)
+	}
+
+	return st.Status(e.Code)
+}
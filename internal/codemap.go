@@ -0,0 +1,79 @@
+package internal
+
+import "reflect"
+
+// Coder is the subset of the errors.ErrorCoder interface needed to key a
+// CodeMap.
+type Coder interface {
+	// Int returns the integer value of the code.
+	Int() int
+}
+
+// code_key is the key of a CodeMap entry.
+type code_key struct {
+	// type_of is the concrete type of the code.
+	type_of reflect.Type
+
+	// value is the integer value of the code.
+	value int
+}
+
+// new_code_key creates the key of the given code.
+//
+// Parameters:
+//   - code: The code. Assumed to be non-nil.
+//
+// Returns:
+//   - code_key: The key of the code.
+func new_code_key(code Coder) code_key {
+	return code_key{
+		type_of: reflect.TypeOf(code),
+		value:   code.Int(),
+	}
+}
+
+// CodeMap maps error codes to values. Codes are identified by their concrete
+// type and integer value, so codes of different types that share an integer
+// value are distinct. The zero value is an empty map ready to use.
+//
+// A CodeMap is not safe for concurrent use; its owner must synchronize the
+// accesses.
+type CodeMap[V any] struct {
+	// table is the mapping of code keys to values.
+	table map[code_key]V
+}
+
+// Set maps the code to the given value. Does nothing if the code is nil.
+//
+// Parameters:
+//   - code: The code to map.
+//   - value: The value.
+func (cm *CodeMap[V]) Set(code Coder, value V) {
+	if code == nil {
+		return
+	}
+
+	if cm.table == nil {
+		cm.table = make(map[code_key]V)
+	}
+
+	cm.table[new_code_key(code)] = value
+}
+
+// Get returns the value mapped to the code.
+//
+// Parameters:
+//   - code: The code to look up.
+//
+// Returns:
+//   - V: The value. The zero value if the code is not mapped.
+//   - bool: True if the code is mapped, false otherwise.
+func (cm *CodeMap[V]) Get(code Coder) (V, bool) {
+	if code == nil {
+		var zero V
+		return zero, false
+	}
+
+	value, ok := cm.table[new_code_key(code)]
+	return value, ok
+}
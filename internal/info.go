@@ -1,21 +1,43 @@
 package internal
 
+import "time"
+
 // Info contains additional information about the error.
 type Info struct {
+	// Template is the message template of the error. Empty if the message
+	// is not rendered from a template.
+	Template string
+
+	// TemplateArgs are the named arguments of the message template.
+	TemplateArgs map[string]any
+
 	// Suggestions is a list of suggestions for the user.
-	// Suggestions []string
+	Suggestions []string
 
 	// Timestamp is the timestamp of the error.
 	// Timestamp time.Time
 
 	// Context is the context of the error.
-	// Context map[string]any
+	Context map[string]any
+
+	// Frames is the list of frames manually added to the error.
+	Frames []string
+
+	// Callers are the program counters captured when the error was created.
+	Callers []uintptr
+
+	// Spans are the source spans the error refers to. The first one is the
+	// primary span.
+	Spans []Span
+
+	// Retryability is the retry classification of the error. 0 if unknown.
+	Retryability int
 
-	// StackTrace is the stack trace of the error.
-	// StackTrace []string
+	// RetryAfter is the minimum delay before retrying. 0 if unspecified.
+	RetryAfter time.Duration
 
 	// Inner is the inner error of the error.
-	// Inner error
+	Inner error
 }
 
 // IsNil implements the errors.Pointer interface.
@@ -29,11 +51,19 @@ func (info *Info) IsNil() bool {
 //   - *Info: A pointer to the new Info. Never returns nil.
 func NewInfo() *Info {
 	return &Info{
-		// Suggestions: nil,
+		Template:     "",
+		TemplateArgs: nil,
+		Suggestions:  nil,
 		// Timestamp:   time.Now(),
-		// Context:    nil,
-		// StackTrace: make([]string, 0),
-		// Inner: nil,
+		Context: nil,
+		Frames:  nil,
+		Callers: nil,
+		Spans:   nil,
+
+		Retryability: 0,
+		RetryAfter:   0,
+
+		Inner: nil,
 	}
 }
 
@@ -46,35 +76,67 @@ func (info *Info) Copy() *Info {
 		return NewInfo()
 	}
 
-	// suggestions := make([]string, len(info.Suggestions))
-	// copy(suggestions, info.Suggestions)
+	var template_args map[string]any
+
+	if len(info.TemplateArgs) > 0 {
+		template_args = make(map[string]any, len(info.TemplateArgs))
 
-	// var context map[string]any
+		for key, value := range info.TemplateArgs {
+			template_args[key] = value
+		}
+	}
 
-	// if info.Context == nil {
-	// 	context = make(map[string]any)
-	// } else {
-	// 	context = make(map[string]any, len(info.Context))
+	var suggestions []string
 
-	// 	for key, value := range info.Context {
-	// 		context[key] = value
-	// 	}
-	// }
+	if len(info.Suggestions) > 0 {
+		suggestions = make([]string, len(info.Suggestions))
+		copy(suggestions, info.Suggestions)
+	}
 
-	// var stack_trace []string
+	var context map[string]any
 
-	// if info.StackTrace == nil {
-	// 	stack_trace = make([]string, 0)
-	// } else {
-	// 	stack_trace = make([]string, len(info.StackTrace))
-	// 	copy(stack_trace, info.StackTrace)
-	// }
+	if len(info.Context) > 0 {
+		context = make(map[string]any, len(info.Context))
+
+		for key, value := range info.Context {
+			context[key] = value
+		}
+	}
+
+	var frames []string
+
+	if len(info.Frames) > 0 {
+		frames = make([]string, len(info.Frames))
+		copy(frames, info.Frames)
+	}
+
+	var callers []uintptr
+
+	if len(info.Callers) > 0 {
+		callers = make([]uintptr, len(info.Callers))
+		copy(callers, info.Callers)
+	}
+
+	var spans []Span
+
+	if len(info.Spans) > 0 {
+		spans = make([]Span, len(info.Spans))
+		copy(spans, info.Spans)
+	}
 
 	return &Info{
-		// Suggestions: suggestions,
+		Template:     info.Template,
+		TemplateArgs: template_args,
+		Suggestions:  suggestions,
 		// Timestamp:   info.Timestamp,
-		// Context:    context,
-		// StackTrace: stack_trace,
-		// Inner: info.Inner,
+		Context: context,
+		Frames:  frames,
+		Callers: callers,
+		Spans:   spans,
+
+		Retryability: info.Retryability,
+		RetryAfter:   info.RetryAfter,
+
+		Inner: info.Inner,
 	}
 }
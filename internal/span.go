@@ -0,0 +1,48 @@
+package internal
+
+import "strconv"
+
+// Span is a region of a source file.
+type Span struct {
+	// File is the path of the source file.
+	File string `json:"file,omitempty"`
+
+	// Line is the 1-based line of the start of the span. If 0, it is
+	// computed from Offset when the source is available.
+	Line int `json:"line,omitempty"`
+
+	// Column is the 1-based column, in bytes, of the start of the span. If 0,
+	// it is computed from Offset when the source is available.
+	Column int `json:"column,omitempty"`
+
+	// Offset is the 0-based byte offset of the start of the span.
+	Offset int `json:"offset"`
+
+	// Length is the length, in bytes, of the span.
+	Length int `json:"length,omitempty"`
+
+	// Label is an optional message attached to the span.
+	Label string `json:"label,omitempty"`
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Format:
+//
+//	<file>:<line>:<column>
+func (s Span) String() string {
+	file := s.File
+	if file == "" {
+		file = "<unknown>"
+	}
+
+	if s.Line <= 0 {
+		return file + ":+" + strconv.Itoa(s.Offset)
+	}
+
+	if s.Column <= 0 {
+		return file + ":" + strconv.Itoa(s.Line)
+	}
+
+	return file + ":" + strconv.Itoa(s.Line) + ":" + strconv.Itoa(s.Column)
+}
@@ -0,0 +1,387 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The severity level is encoded as its name, or as its integer value if it is
+// out of range so that encoding never fails.
+func (s SeverityLevel) MarshalJSON() ([]byte, error) {
+	if !s.IsValid() {
+		return json.Marshal(int(s))
+	}
+
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Both the name, case-insensitively, and the integer value of the severity
+// level are accepted. Integers are accepted even if out of range so that
+// every encoded severity level can be decoded.
+func (s *SeverityLevel) UnmarshalJSON(data []byte) error {
+	if s == nil {
+		return NewErrNilReceiver("SeverityLevel.UnmarshalJSON()")
+	}
+
+	var name string
+
+	err := json.Unmarshal(data, &name)
+	if err == nil {
+		return s.UnmarshalText([]byte(name))
+	}
+
+	var value int
+
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return NewErrInvalidParameter("SeverityLevel.UnmarshalJSON()", "severity level must be a string or an integer")
+	}
+
+	*s = SeverityLevel(value)
+
+	return nil
+}
+
+// json_safe returns the values that encoding/json can encode.
+//
+// Parameters:
+//   - values: The values to check.
+//
+// Returns:
+//   - map[string]any: values itself if every value can be encoded, or a copy
+//     where the values that cannot be encoded (e.g., functions or channels)
+//     are replaced by their fmt.Sprint representation.
+func json_safe(values map[string]any) map[string]any {
+	var safe map[string]any
+
+	for key, value := range values {
+		_, err := json.Marshal(value)
+		if err == nil {
+			continue
+		}
+
+		if safe == nil {
+			safe = make(map[string]any, len(values))
+
+			for k, v := range values {
+				safe[k] = v
+			}
+		}
+
+		safe[key] = fmt.Sprint(value)
+	}
+
+	if safe == nil {
+		return values
+	}
+
+	return safe
+}
+
+// json_code is the JSON representation of an error code.
+type json_code struct {
+	// Namespace is the namespace the code type is registered under.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the code.
+	Name string `json:"name"`
+
+	// Value is the integer value of the code.
+	Value int `json:"value"`
+}
+
+// json_err is the JSON representation of an error.
+//
+// Errors that are not *Err are represented with only a message and,
+// optionally, their causes.
+type json_err struct {
+	// Severity is the severity level of the error.
+	Severity *SeverityLevel `json:"severity,omitempty"`
+
+	// Code is the error code.
+	Code *json_code `json:"code,omitempty"`
+
+	// Message is the error message.
+	Message string `json:"message"`
+
+	// Template is the message template of the error.
+	Template string `json:"template,omitempty"`
+
+	// TemplateArgs are the named arguments of the message template.
+	TemplateArgs map[string]any `json:"template_args,omitempty"`
+
+	// Suggestions are the suggestions of the error.
+	Suggestions []string `json:"suggestions,omitempty"`
+
+	// Context is the context of the error.
+	Context map[string]any `json:"context,omitempty"`
+
+	// Frames are the frames manually added to the error.
+	Frames []string `json:"frames,omitempty"`
+
+	// Spans are the source spans of the error.
+	Spans []Span `json:"spans,omitempty"`
+
+	// Retry is the retry classification of the error, if any.
+	Retry string `json:"retry,omitempty"`
+
+	// RetryAfter is the minimum delay before retrying, if any.
+	RetryAfter string `json:"retry_after,omitempty"`
+
+	// Stack is the resolved stack trace of the error.
+	Stack []string `json:"stack,omitempty"`
+
+	// Cause is the inner error.
+	Cause *json_err `json:"cause,omitempty"`
+
+	// Causes are the inner errors when more than one is wrapped.
+	Causes []*json_err `json:"causes,omitempty"`
+}
+
+// to_json_err converts an error into its JSON representation.
+//
+// Parameters:
+//   - err: The error to convert.
+//
+// Returns:
+//   - *json_err: The JSON representation. Nil if err is nil.
+func to_json_err(err error) *json_err {
+	if err == nil {
+		return nil
+	}
+
+	e, ok := err.(*Err)
+	if !ok {
+		je := &json_err{
+			Message: err.Error(),
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, sub := range x.Unwrap() {
+				if sub != nil {
+					je.Causes = append(je.Causes, to_json_err(sub))
+				}
+			}
+		case interface{ Unwrap() error }:
+			je.Cause = to_json_err(x.Unwrap())
+		}
+
+		return je
+	} else if e == nil {
+		return nil
+	}
+
+	severity := e.Severity
+
+	je := &json_err{
+		Severity: &severity,
+		Message:  e.RenderMessage(),
+	}
+
+	if e.Code != nil {
+		namespace, _ := CodeNamespace(e.Code)
+
+		je.Code = &json_code{
+			Namespace: namespace,
+			Name:      e.Code.String(),
+			Value:     e.Code.Int(),
+		}
+	}
+
+	if e.Info == nil {
+		return je
+	}
+
+	je.Template = e.Info.Template
+	je.TemplateArgs = json_safe(e.Info.TemplateArgs)
+	je.Suggestions = e.Info.Suggestions
+	je.Context = json_safe(e.Info.Context)
+	je.Frames = e.Info.Frames
+	je.Spans = e.Info.Spans
+
+	if e.Info.Retryability != int(RetryUnknown) {
+		je.Retry = Retryability(e.Info.Retryability).String()
+	}
+
+	if e.Info.RetryAfter > 0 {
+		je.RetryAfter = e.Info.RetryAfter.String()
+	}
+
+	for _, frame := range resolve_frames(e.Info.Callers) {
+		je.Stack = append(je.Stack, frame.String())
+	}
+
+	if e.Info.Inner == nil {
+		return je
+	}
+
+	joined, ok := e.Info.Inner.(interface{ Unwrap() []error })
+	if ok {
+		for _, sub := range joined.Unwrap() {
+			if sub != nil {
+				je.Causes = append(je.Causes, to_json_err(sub))
+			}
+		}
+	} else {
+		je.Cause = to_json_err(e.Info.Inner)
+	}
+
+	return je
+}
+
+// from_json_err converts a JSON representation back into an error.
+//
+// Parameters:
+//   - je: The JSON representation.
+//
+// Returns:
+//   - error: The error. Nil if je is nil.
+func from_json_err(je *json_err) error {
+	if je == nil {
+		return nil
+	}
+
+	var inner error
+
+	if je.Cause != nil {
+		inner = from_json_err(je.Cause)
+	} else if len(je.Causes) > 0 {
+		causes := make([]error, 0, len(je.Causes))
+
+		for _, sub := range je.Causes {
+			causes = append(causes, from_json_err(sub))
+		}
+
+		inner = errors.Join(causes...)
+	}
+
+	if je.Severity == nil && je.Code == nil {
+		if inner == nil {
+			return errors.New(je.Message)
+		}
+
+		return &foreign_err{
+			message: je.Message,
+			inner:   inner,
+		}
+	}
+
+	e := &Err{
+		Message: je.Message,
+		Info:    internal.NewInfo(),
+	}
+
+	if je.Severity != nil {
+		e.Severity = *je.Severity
+	}
+
+	if je.Code != nil {
+		code, ok := LookupCode(je.Code.Namespace, je.Code.Value)
+		if !ok {
+			code = UnknownCode{
+				NS:    je.Code.Namespace,
+				Name:  je.Code.Name,
+				Value: je.Code.Value,
+			}
+		}
+
+		e.Code = code
+	}
+
+	e.Info.Template = je.Template
+	e.Info.TemplateArgs = je.TemplateArgs
+	e.Info.Suggestions = je.Suggestions
+	e.Info.Context = je.Context
+	e.Info.Frames = je.Frames
+	e.Info.Spans = je.Spans
+
+	for class := RetryTransient; class <= RetryPermanent; class++ {
+		if strings.EqualFold(class.String(), je.Retry) {
+			e.Info.Retryability = int(class)
+			break
+		}
+	}
+
+	after, err := time.ParseDuration(je.RetryAfter)
+	if err == nil && after > 0 {
+		e.Info.RetryAfter = after
+	}
+
+	e.Info.Inner = inner
+
+	return e
+}
+
+// foreign_err is a decoded error that was not an *Err but wrapped another
+// error.
+type foreign_err struct {
+	// message is the message of the error.
+	message string
+
+	// inner is the inner error.
+	inner error
+}
+
+// Error implements the error interface.
+func (e *foreign_err) Error() string {
+	return e.message
+}
+
+// Unwrap returns the inner error.
+func (e *foreign_err) Unwrap() error {
+	return e.inner
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The code is encoded with the namespace its type is registered under (see
+// RegisterCode) so that UnmarshalJSON can reconstruct the concrete code type.
+// Context values and template arguments that encoding/json cannot encode
+// are encoded with fmt.Sprint.
+// The stack trace is encoded as resolved frames for informational purposes
+// only and is not restored by UnmarshalJSON.
+func (e *Err) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(to_json_err(e))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Codes whose namespace is not registered are decoded as UnknownCode. Context
+// values are decoded with the default encoding/json types (e.g., numbers
+// become float64).
+func (e *Err) UnmarshalJSON(data []byte) error {
+	if e == nil {
+		return NewErrNilReceiver("Err.UnmarshalJSON()")
+	}
+
+	var je json_err
+
+	err := json.Unmarshal(data, &je)
+	if err != nil {
+		return err
+	}
+
+	if je.Code == nil && je.Severity == nil {
+		return NewErrInvalidParameter("Err.UnmarshalJSON()", "data does not represent an *Err")
+	}
+
+	decoded := from_json_err(&je).(*Err)
+
+	*e = *decoded
+
+	return nil
+}
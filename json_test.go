@@ -0,0 +1,213 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// json_test_code is a code type registered for the JSON round trip tests.
+type json_test_code int
+
+func (c json_test_code) Int() int       { return int(c) }
+func (c json_test_code) String() string { return fmt.Sprintf("Code%d", int(c)) }
+
+func init() {
+	err := RegisterCode[json_test_code]("jsontest")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// round_trip encodes and decodes the error.
+func round_trip(t *testing.T, err *Err) (*Err, []byte) {
+	t.Helper()
+
+	data, m_err := json.Marshal(err)
+	if m_err != nil {
+		t.Fatalf("Marshal() = %v", m_err)
+	}
+
+	var back Err
+
+	u_err := json.Unmarshal(data, &back)
+	if u_err != nil {
+		t.Fatalf("Unmarshal(%s) = %v", data, u_err)
+	}
+
+	return &back, data
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	inner := New(NoSuchKey, "missing")
+
+	err := NewWithSeverity(WARNING, json_test_code(2), "outer")
+	err.AddSuggestion("Try again")
+	err.AddContext("id", 42)
+	err.AddFrame("Outer()")
+	err.MarkTransient(2 * time.Second)
+	err.SetInner(inner)
+	err.AddInner(fmt.Errorf("plain"))
+
+	back, _ := round_trip(t, err)
+
+	if !Is(back, json_test_code(2)) {
+		t.Errorf("Is(json_test_code(2)) = false after decoding, want true")
+	}
+
+	if code, ok := back.Code.(json_test_code); !ok || code != 2 {
+		t.Errorf("Code = %#v, want json_test_code(2)", back.Code)
+	}
+
+	if !Is(back, NoSuchKey) {
+		t.Errorf("Is(NoSuchKey) = false after decoding, want true")
+	}
+
+	if Is(back, BadParameter) {
+		t.Errorf("Is(BadParameter) = true after decoding, want false")
+	}
+
+	if back.Severity != WARNING || back.Message != "outer" {
+		t.Errorf("decoded = (%v, %q), want (WARNING, %q)", back.Severity, back.Message, "outer")
+	}
+
+	if !reflect.DeepEqual(back.Suggestions(), []string{"Try again"}) {
+		t.Errorf("Suggestions() = %v, want [Try again]", back.Suggestions())
+	}
+
+	if id, _ := back.Value("id"); id != float64(42) {
+		t.Errorf("Value(id) = %#v, want float64(42)", id)
+	}
+
+	if !reflect.DeepEqual(back.Info.Frames, []string{"Outer()"}) {
+		t.Errorf("Frames = %v, want [Outer()]", back.Info.Frames)
+	}
+
+	if class, after := Classify(back); class != RetryTransient || after != 2*time.Second {
+		t.Errorf("Classify() = (%v, %v), want (%v, 2s)", class, after, RetryTransient)
+	}
+
+	if !strings.Contains(back.Unwrap().Error(), "plain") {
+		t.Errorf("Unwrap() = %v, want the joined causes", back.Unwrap())
+	}
+}
+
+func TestJSONUnknownNamespace(t *testing.T) {
+	data := []byte(`{"severity":"ERROR","code":{"namespace":"other","name":"Gone","value":7},"message":"gone"}`)
+
+	var back Err
+
+	err := json.Unmarshal(data, &back)
+	if err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+
+	want := UnknownCode{NS: "other", Name: "Gone", Value: 7}
+	if back.Code != want {
+		t.Errorf("Code = %#v, want %#v", back.Code, want)
+	}
+
+	if !Is(&back, want) {
+		t.Errorf("Is(UnknownCode) = false, want true")
+	}
+}
+
+func TestJSONUnencodableValues(t *testing.T) {
+	err := New(BadParameter, "bad")
+	err.AddContext("callback", func() {})
+	err.AddContext("events", make(chan int))
+	err.AddContext("id", 42)
+
+	back, data := round_trip(t, err)
+
+	for _, key := range []string{"callback", "events"} {
+		value, ok := back.Value(key)
+		if s, is_str := value.(string); !ok || !is_str || s == "" {
+			t.Errorf("Value(%q) = %#v, want its fmt.Sprint representation", key, value)
+		}
+	}
+
+	if id, _ := back.Value("id"); id != float64(42) {
+		t.Errorf("Value(id) = %#v, want float64(42) in %s", id, data)
+	}
+
+	if _, ok := err.Value("callback"); !ok {
+		t.Errorf("MarshalJSON() modified the context of the error")
+	} else if _, is_str := err.Info.Context["callback"].(string); is_str {
+		t.Errorf("MarshalJSON() replaced the context value of the error")
+	}
+
+	var buf bytes.Buffer
+
+	r_err := JSONRenderer{}.Render(&buf, err)
+	if r_err != nil {
+		t.Errorf("JSONRenderer.Render() = %v, want nil", r_err)
+	}
+}
+
+func TestJSONSeverityOutOfRange(t *testing.T) {
+	err := NewWithSeverity(SeverityLevel(7), BadParameter, "odd")
+
+	back, data := round_trip(t, err)
+
+	if !bytes.Contains(data, []byte(`"severity":7`)) {
+		t.Errorf("MarshalJSON() = %s, want the integer severity 7", data)
+	}
+
+	if back.Severity != 7 {
+		t.Errorf("Severity = %d, want 7", back.Severity)
+	}
+
+	var buf bytes.Buffer
+
+	r_err := JSONRenderer{}.Render(&buf, err)
+	if r_err != nil {
+		t.Errorf("JSONRenderer.Render() = %v, want nil", r_err)
+	}
+}
+
+func TestSeverityLevelJSON(t *testing.T) {
+	tests := []struct {
+		level SeverityLevel
+		want  string
+	}{
+		{INFO, `"INFO"`},
+		{FATAL, `"FATAL"`},
+		{SeverityLevel(-1), `-1`},
+		{SeverityLevel(4), `4`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.level)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("Marshal(%d) = (%s, %v), want %s", int(tt.level), data, err, tt.want)
+		}
+
+		var back SeverityLevel
+
+		err = json.Unmarshal(data, &back)
+		if err != nil || back != tt.level {
+			t.Errorf("Unmarshal(%s) = (%d, %v), want %d", data, int(back), err, int(tt.level))
+		}
+	}
+
+	var level SeverityLevel
+
+	for _, data := range []string{`"warning"`, `1`} {
+		err := json.Unmarshal([]byte(data), &level)
+		if err != nil || level != WARNING {
+			t.Errorf("Unmarshal(%s) = (%v, %v), want WARNING", data, level, err)
+		}
+	}
+
+	for _, data := range []string{`"nope"`, `true`} {
+		err := json.Unmarshal([]byte(data), &level)
+		if err == nil {
+			t.Errorf("Unmarshal(%s) = nil, want an error", data)
+		}
+	}
+}
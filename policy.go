@@ -0,0 +1,288 @@
+package errors
+
+import (
+	"io"
+	"reflect"
+)
+
+// Matcher reports whether a rule applies to an error.
+//
+// Parameters:
+//   - e: The error to check. Never nil.
+//
+// Returns:
+//   - bool: True if the rule applies, false otherwise.
+type Matcher func(e *Err) bool
+
+// MatchCode returns a matcher of the errors with the given code (same
+// concrete type and same integer value).
+//
+// Parameters:
+//   - code: The code to match.
+//
+// Returns:
+//   - Matcher: The matcher. Never returns nil.
+func MatchCode[T ErrorCoder](code T) Matcher {
+	return func(e *Err) bool {
+		return has_code(e, code)
+	}
+}
+
+// own_package is the import path of this package.
+var own_package string = reflect.TypeFor[Err]().PkgPath()
+
+// MatchPackage returns a matcher of the errors created in the given package,
+// according to their captured stack trace.
+//
+// Parameters:
+//   - pkg: The import path of the package.
+//
+// Returns:
+//   - Matcher: The matcher. Never returns nil.
+//
+// Frames of this package are skipped, so that errors created through its
+// helpers (e.g., NewErrAt or NewTemplate) are attributed to their caller.
+// Errors without a captured stack trace never match.
+func MatchPackage(pkg string) Matcher {
+	return func(e *Err) bool {
+		if e.Info == nil || len(e.Info.Callers) == 0 {
+			return false
+		}
+
+		frames := resolve_frames(e.Info.Callers)
+		if len(frames) == 0 {
+			return false
+		}
+
+		for _, frame := range frames {
+			frame_pkg := frame.Package()
+			if frame_pkg != own_package {
+				return frame_pkg == pkg
+			}
+		}
+
+		return pkg == own_package
+	}
+}
+
+// MatchAll returns a matcher of the errors that satisfy all the matchers.
+//
+// Parameters:
+//   - matchers: The matchers. Nil matchers are ignored.
+//
+// Returns:
+//   - Matcher: The matcher. Never returns nil.
+func MatchAll(matchers ...Matcher) Matcher {
+	return func(e *Err) bool {
+		for _, m := range matchers {
+			if m != nil && !m(e) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Rule escalates the severity of the errors it matches.
+type Rule struct {
+	// Match selects the errors the rule applies to. If nil, the rule applies
+	// to every error.
+	Match Matcher
+
+	// Severity is the minimum severity level of the matching errors.
+	Severity SeverityLevel
+}
+
+// Policy decides the effective severity of errors and which ones are
+// reported.
+//
+// The zero value reports every error with its own severity.
+type Policy struct {
+	// MinSeverity is the minimum effective severity level of the reported
+	// errors.
+	MinSeverity SeverityLevel
+
+	// WarningsAsErrors escalates warnings to errors, after the rules are
+	// applied.
+	WarningsAsErrors bool
+
+	// Rules are the escalation rules. Rules never lower a severity level.
+	Rules []Rule
+}
+
+// Severity returns the effective severity level of the error.
+//
+// Parameters:
+//   - e: The error.
+//
+// Returns:
+//   - SeverityLevel: The effective severity level. INFO if e is nil.
+func (p *Policy) Severity(e *Err) SeverityLevel {
+	if e == nil {
+		return INFO
+	}
+
+	level := e.Severity
+
+	if p == nil {
+		return level
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Match == nil || rule.Match(e) {
+			level = max(level, rule.Severity)
+		}
+	}
+
+	if p.WarningsAsErrors && level == WARNING {
+		level = ERROR
+	}
+
+	return level
+}
+
+// severity_of returns the effective severity level of any error.
+//
+// Parameters:
+//   - err: The error. Assumed to be non-nil.
+//
+// Returns:
+//   - SeverityLevel: The effective severity level. Errors that are not *Err
+//     are considered of severity ERROR, and aggregates have the highest
+//     effective severity level of their errors.
+func (p *Policy) severity_of(err error) SeverityLevel {
+	switch x := err.(type) {
+	case *Err:
+		if x != nil {
+			return p.Severity(x)
+		}
+	case *Aggregate:
+		errs := x.Errors()
+		if len(errs) == 0 {
+			return INFO
+		}
+
+		level := p.severity_of(errs[0])
+
+		for _, sub := range errs[1:] {
+			level = max(level, p.severity_of(sub))
+		}
+
+		return level
+	}
+
+	return ERROR
+}
+
+// Allows checks whether the error is reported by the policy.
+//
+// Parameters:
+//   - err: The error to check.
+//
+// Returns:
+//   - bool: True if the effective severity level of the error is at least
+//     MinSeverity, false otherwise (including if err is nil).
+func (p *Policy) Allows(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if p == nil {
+		return true
+	}
+
+	return p.severity_of(err) >= p.MinSeverity
+}
+
+// Apply changes, in place, the severity level of every *Err of the chain to
+// its effective severity level.
+//
+// Parameters:
+//   - err: The error chain. Aggregates are walked as well.
+//
+// Returns:
+//   - error: err, for convenience.
+func (p *Policy) Apply(err error) error {
+	if p == nil || err == nil {
+		return err
+	}
+
+	if e, ok := err.(*Err); ok && e != nil {
+		e.ChangeSeverity(p.Severity(e))
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, sub := range x.Unwrap() {
+			p.Apply(sub)
+		}
+	case interface{ Unwrap() error }:
+		p.Apply(x.Unwrap())
+	}
+
+	return err
+}
+
+// Filter returns a new aggregate with the errors reported by the policy.
+//
+// Parameters:
+//   - agg: The aggregate to filter.
+//
+// Returns:
+//   - *Aggregate: A pointer to the new Aggregate. Never returns nil.
+func (p *Policy) Filter(agg *Aggregate) *Aggregate {
+	return agg.Filter(p.Allows)
+}
+
+// Renderer returns a renderer that applies the policy before delegating to r.
+//
+// Parameters:
+//   - r: The renderer to delegate to. If nil, the default renderer at the
+//     time of rendering is used.
+//
+// Returns:
+//   - Renderer: The renderer. Never returns nil.
+//
+// The rendered error is a copy with the effective severity levels applied;
+// the original error is not modified. Errors that the policy does not allow
+// are not rendered and the errors of aggregates are filtered.
+func (p *Policy) Renderer(r Renderer) Renderer {
+	return &policy_renderer{
+		policy:   p,
+		renderer: r,
+	}
+}
+
+// policy_renderer is the renderer returned by Policy.Renderer.
+type policy_renderer struct {
+	// policy is the policy to apply.
+	policy *Policy
+
+	// renderer is the renderer to delegate to. May be nil.
+	renderer Renderer
+}
+
+// Render implements the Renderer interface.
+func (pr *policy_renderer) Render(w io.Writer, to_display error) error {
+	if !pr.policy.Allows(to_display) {
+		return nil
+	}
+
+	if agg, ok := to_display.(*Aggregate); ok {
+		to_display = pr.policy.Filter(agg)
+	}
+
+	view := pr.policy.Apply(LimitErrorMsg(to_display, 0, 0))
+
+	r := pr.renderer
+	if r == nil {
+		r = DefaultRenderer()
+	}
+
+	if r == pr {
+		r = TextRenderer{}
+	}
+
+	return r.Render(w, view)
+}
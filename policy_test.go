@@ -0,0 +1,102 @@
+package errors_test
+
+import (
+	"reflect"
+	"testing"
+
+	errors "github.com/PlayerR9/go-errors"
+	"github.com/PlayerR9/go-errors/httperr"
+)
+
+// this_package is the import path of the package of the tests.
+var this_package string = reflect.TypeFor[marker]().PkgPath()
+
+// marker is used to find the import path of the package of the tests.
+type marker struct{}
+
+func TestMatchPackage(t *testing.T) {
+	own_package := reflect.TypeFor[errors.Err]().PkgPath()
+
+	tests := []struct {
+		name string
+		err  *errors.Err
+	}{
+		{"New", errors.New(errors.OperationFail, "fail")},
+		{"NewErrAt", errors.NewErrAt("step", nil)},
+		{"NewErrNilParameter", errors.NewErrNilParameter("TestMatchPackage()", "x")},
+		{"NewErrNoSuchKey", errors.NewErrNoSuchKey("TestMatchPackage()", "key")},
+		{"NewTemplate", errors.NewTemplate(errors.NoSuchKey, "key {key:q}", map[string]any{"key": "k"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.MatchPackage(this_package)(tt.err) {
+				t.Errorf("MatchPackage(%q) does not match an error created by the test", this_package)
+			}
+
+			if errors.MatchPackage(own_package)(tt.err) {
+				t.Errorf("MatchPackage(%q) matches an error created by the test", own_package)
+			}
+		})
+	}
+}
+
+func TestMatchPackageOtherPackage(t *testing.T) {
+	httperr_package := reflect.TypeFor[httperr.Problem]().PkgPath()
+
+	w_err := httperr.WriteProblem(nil, nil, nil, errors.New(errors.OperationFail, "fail"))
+
+	err, ok := errors.As(w_err)
+	if !ok {
+		t.Fatalf("WriteProblem() = %v, want an *Err", w_err)
+	}
+
+	if errors.MatchPackage(this_package)(err) {
+		t.Errorf("MatchPackage(%q) matches an error created by %s", this_package, httperr_package)
+	}
+
+	if !errors.MatchPackage(httperr_package)(err) {
+		t.Errorf("MatchPackage(%q) does not match an error created by it", httperr_package)
+	}
+}
+
+func TestMatchPackageWithoutStack(t *testing.T) {
+	enabled := errors.StackCaptureEnabled()
+	errors.SetStackCapture(false)
+
+	defer errors.SetStackCapture(enabled)
+
+	err := errors.New(errors.OperationFail, "fail")
+
+	if errors.MatchPackage(this_package)(err) {
+		t.Errorf("MatchPackage() matches an error without a stack trace")
+	}
+}
+
+func TestPolicyEscalatesByPackage(t *testing.T) {
+	p := &errors.Policy{
+		Rules: []errors.Rule{
+			{
+				Match:    errors.MatchAll(errors.MatchCode(errors.OperationFail), errors.MatchPackage(this_package)),
+				Severity: errors.FATAL,
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		err  *errors.Err
+		want errors.SeverityLevel
+	}{
+		{"matching code and package", errors.NewErrAt("step", nil), errors.FATAL},
+		{"other code", errors.NewErrNoSuchKey("TestPolicyEscalatesByPackage()", "key"), errors.ERROR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Severity(tt.err); got != tt.want {
+				t.Errorf("Severity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// FromPanic converts a recovered value into a FATAL error.
+//
+// Parameters:
+//   - r: The recovered value.
+//
+// Returns:
+//   - *Err: The error. Nil if r is nil.
+//
+// If r is an *Err, a copy of it with the severity FATAL is returned; r itself
+// is not modified. If r is any other error, it is wrapped with the code
+// OperationFail. Otherwise, a new error with the code OperationFail is
+// created from the value. Unless r is an *Err, the stack trace is captured
+// from the caller of FromPanic which, when called from a deferred function,
+// includes the frames of the panic.
+func FromPanic(r any) *Err {
+	if r == nil {
+		return nil
+	}
+
+	var err *Err
+
+	switch x := r.(type) {
+	case *Err:
+		if x == nil {
+			return nil
+		}
+
+		err = &Err{
+			Code:    x.Code,
+			Message: x.Message,
+			Info:    x.Info.Copy(),
+		}
+	case error:
+		err = &Err{
+			Code:    OperationFail,
+			Message: "panic: " + x.Error(),
+			Info:    internal.NewInfo(),
+		}
+
+		err.Info.Inner = x
+		err.Info.Callers = capture_callers(1)
+	default:
+		err = &Err{
+			Code:    OperationFail,
+			Message: fmt.Sprintf("panic: %v", x),
+			Info:    internal.NewInfo(),
+		}
+
+		err.Info.Callers = capture_callers(1)
+	}
+
+	err.Severity = FATAL
+
+	return err
+}
+
+// Recover recovers from a panic and stores it, as a FATAL error, into the
+// given error. Does nothing if there is no panic or err is nil.
+//
+// Parameters:
+//   - err: The error to store the panic into.
+//
+// It must be deferred directly:
+//
+//	func f() (err error) {
+//		defer errors.Recover(&err)
+//		...
+//	}
+//
+// If err already holds an error, that error becomes an inner error of the
+// panic error, unless it is the panic value itself or part of its chain
+// (e.g., after err = e; panic(e)).
+func Recover(err *error) {
+	if err == nil {
+		return
+	}
+
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	p_err := FromPanic(r)
+
+	cause, _ := r.(error)
+
+	if !in_chain(p_err, *err) && !in_chain(cause, *err) {
+		p_err.AddInner(*err)
+	}
+
+	*err = p_err
+}
+
+// in_chain checks whether the target is part of the chain of err, by
+// identity.
+//
+// Parameters:
+//   - err: The error whose chain is walked. Joined errors are walked as well.
+//   - target: The error to look for.
+//
+// Returns:
+//   - bool: True if target is err or one of the errors it wraps, false
+//     otherwise (including if either is nil or target is not comparable).
+func in_chain(err, target error) bool {
+	if err == nil || target == nil || !reflect.TypeOf(target).Comparable() {
+		return false
+	}
+
+	for err != nil {
+		if err == target {
+			return true
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, sub := range x.Unwrap() {
+				if in_chain(sub, target) {
+					return true
+				}
+			}
+
+			return false
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// Try calls the function and converts any panic into a FATAL error.
+//
+// Parameters:
+//   - fn: The function to call.
+//
+// Returns:
+//   - error: The error returned by fn, or the FATAL error of the panic.
+func Try(fn func() error) (err error) {
+	if fn == nil {
+		return NewErrNilParameter("Try()", "fn")
+	}
+
+	defer Recover(&err)
+
+	err = fn()
+
+	return err
+}
+
+// Must returns the value if err is nil. Otherwise, it displays the error
+// to os.Stderr and panics with it (see Panic).
+//
+// Parameters:
+//   - value: The value to return.
+//   - err: The error to check.
+//
+// Returns:
+//   - T: The value.
+//
+// Errors that are not *Err are wrapped with the code OperationFail and *Err
+// values are copied, so that err is never modified. In all cases, the
+// severity of the panic error is FATAL.
+func Must[T any](value T, err error) T {
+	if err == nil {
+		return value
+	}
+
+	e, ok := err.(*Err)
+	if !ok {
+		e = NewFromError(OperationFail, err)
+	} else if e == nil {
+		return value
+	} else {
+		e = &Err{
+			Severity: e.Severity,
+			Code:     e.Code,
+			Message:  e.Message,
+			Info:     e.Info.Copy(),
+		}
+	}
+
+	e.ChangeSeverity(FATAL)
+
+	Panic(os.Stderr, e)
+
+	return value
+}
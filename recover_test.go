@@ -0,0 +1,187 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+// recover_from calls fn with Recover deferred and returns the stored error.
+func recover_from(fn func(err *error)) (err error) {
+	defer Recover(&err)
+
+	fn(&err)
+
+	return err
+}
+
+func TestRecoverSameErr(t *testing.T) {
+	orig := New(NoSuchKey, "missing")
+
+	got := recover_from(func(err *error) {
+		*err = orig
+		panic(orig)
+	})
+
+	e, ok := got.(*Err)
+	if !ok {
+		t.Fatalf("Recover() stored %T, want *Err", got)
+	}
+
+	if errors.Unwrap(e) == error(e) {
+		t.Fatalf("Recover() made the error its own cause")
+	}
+
+	if e.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v, want nil", e.Unwrap())
+	}
+
+	if !Is(got, NoSuchKey) {
+		t.Errorf("Is(NoSuchKey) = false, want true")
+	}
+
+	if e.Severity != FATAL {
+		t.Errorf("Severity = %v, want FATAL", e.Severity)
+	}
+
+	if orig.Severity != ERROR {
+		t.Errorf("Recover() changed the severity of the panic value to %v", orig.Severity)
+	}
+}
+
+func TestRecoverInner(t *testing.T) {
+	prior := New(BadParameter, "prior")
+	eof := io.EOF
+
+	tests := []struct {
+		name string
+		fn   func(err *error)
+
+		// want_inner is the expected inner error of the stored error, if
+		// check_inner is true.
+		want_inner  error
+		check_inner bool
+	}{
+		{
+			name:        "no prior error",
+			fn:          func(err *error) { panic("boom") },
+			want_inner:  nil,
+			check_inner: true,
+		},
+		{
+			name: "prior error",
+			fn: func(err *error) {
+				*err = prior
+				panic("boom")
+			},
+			want_inner:  prior,
+			check_inner: true,
+		},
+		{
+			name: "prior foreign error is the panic value",
+			fn: func(err *error) {
+				*err = eof
+				panic(eof)
+			},
+			want_inner:  eof,
+			check_inner: true,
+		},
+		{
+			name: "prior error wraps the panic value",
+			fn: func(err *error) {
+				*err = fmt.Errorf("wrapped: %w", prior)
+				panic(prior)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := recover_from(tt.fn).(*Err)
+			if !ok {
+				t.Fatalf("Recover() did not store an *Err")
+			}
+
+			if inner := got.Unwrap(); tt.check_inner && inner != tt.want_inner {
+				t.Errorf("Unwrap() = %v, want %v", inner, tt.want_inner)
+			}
+
+			for err := error(got); err != nil; err = errors.Unwrap(err) {
+				if errors.Unwrap(err) == err {
+					t.Fatalf("cycle in the chain of %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestFromPanicCopies(t *testing.T) {
+	orig := New(NoSuchKey, "missing")
+	orig.AddSuggestion("Check the key")
+
+	got := FromPanic(orig)
+
+	if got == orig {
+		t.Fatalf("FromPanic() returned its argument")
+	}
+
+	if got.Severity != FATAL || orig.Severity != ERROR {
+		t.Errorf("severities = (%v, %v), want (FATAL, ERROR)", got.Severity, orig.Severity)
+	}
+
+	got.AddSuggestion("Another")
+
+	if len(orig.Suggestions()) != 1 {
+		t.Errorf("FromPanic() shares the info of its argument")
+	}
+}
+
+func TestMustCopies(t *testing.T) {
+	stderr := os.Stderr
+
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+
+	defer null.Close()
+
+	os.Stderr = null
+	t.Cleanup(func() { os.Stderr = stderr })
+
+	orig := New(NoSuchKey, "missing")
+
+	defer func() {
+		r, ok := recover().(*Err)
+		if !ok {
+			t.Fatalf("Must() did not panic with an *Err")
+		}
+
+		if r.Severity != FATAL {
+			t.Errorf("Severity = %v, want FATAL", r.Severity)
+		}
+
+		if orig.Severity != ERROR {
+			t.Errorf("Must() changed the severity of its argument to %v", orig.Severity)
+		}
+	}()
+
+	Must(0, orig)
+}
+
+func TestTry(t *testing.T) {
+	if err := Try(func() error { return nil }); err != nil {
+		t.Errorf("Try() = %v, want nil", err)
+	}
+
+	err := Try(func() error { panic("boom") })
+	if !Is(err, OperationFail) {
+		t.Errorf("Try() = %v, want an OperationFail error", err)
+	}
+
+	if !Is(Try(nil), BadParameter) {
+		t.Errorf("Try(nil) is not a BadParameter error")
+	}
+}
@@ -0,0 +1,210 @@
+package errors
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// code_entry is an entry of the code registry.
+type code_entry struct {
+	// namespace is the namespace the code type is registered under.
+	namespace string
+
+	// decode converts an integer value into an error code.
+	decode func(value int) (ErrorCoder, bool)
+}
+
+var (
+	// registry_mu protects the registry maps.
+	registry_mu sync.RWMutex
+
+	// registry_by_namespace maps a namespace to its entry.
+	registry_by_namespace map[string]*code_entry
+
+	// registry_by_type maps a code type to its entry.
+	registry_by_type map[reflect.Type]*code_entry
+)
+
+func init() {
+	err := RegisterCode[ErrorCode]("errors")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RegisterCodeFunc registers the error code type C under the given namespace
+// so that it can be reconstructed when decoding errors.
+//
+// Parameters:
+//   - namespace: The namespace of the code type. If empty and C implements
+//     Namespacer, the namespace of the zero value of C is used.
+//   - decode: The function that converts an integer value into a code. It
+//     returns false if the value is not a valid code.
+//
+// Returns:
+//   - error: An error if the registration failed.
+//
+// Errors:
+//   - *Err with code BadParameter: If the namespace is empty or decode is nil.
+//   - *Err with code InvalidUsage: If the namespace or the type is already
+//     registered, or if the namespace differs from the one C declares.
+func RegisterCodeFunc[C ErrorCoder](namespace string, decode func(value int) (C, bool)) error {
+	var zero C
+
+	if n, ok := any(zero).(Namespacer); ok {
+		declared := n.Namespace()
+
+		if namespace == "" {
+			namespace = declared
+		} else if declared != "" && declared != namespace {
+			return NewErrInvalidUsage("RegisterCodeFunc()", "namespace ("+strconv.Quote(namespace)+") differs from the declared namespace ("+strconv.Quote(declared)+")", "Register the code type under the namespace returned by its Namespace method")
+		}
+	}
+
+	if namespace == "" {
+		return NewErrInvalidParameter("RegisterCodeFunc()", "namespace must not be empty")
+	} else if decode == nil {
+		return NewErrNilParameter("RegisterCodeFunc()", "decode")
+	}
+
+	type_of := reflect.TypeFor[C]()
+
+	registry_mu.Lock()
+	defer registry_mu.Unlock()
+
+	if _, ok := registry_by_namespace[namespace]; ok {
+		return NewErrInvalidUsage("RegisterCodeFunc()", "namespace ("+strconv.Quote(namespace)+") is already registered", "Use a different namespace for each code type")
+	}
+
+	if entry, ok := registry_by_type[type_of]; ok {
+		return NewErrInvalidUsage("RegisterCodeFunc()", "type "+type_of.String()+" is already registered under namespace ("+strconv.Quote(entry.namespace)+")", "Register each code type only once")
+	}
+
+	entry := &code_entry{
+		namespace: namespace,
+		decode: func(value int) (ErrorCoder, bool) {
+			code, ok := decode(value)
+			if !ok {
+				return nil, false
+			}
+
+			return code, true
+		},
+	}
+
+	if registry_by_namespace == nil {
+		registry_by_namespace = make(map[string]*code_entry)
+		registry_by_type = make(map[reflect.Type]*code_entry)
+	}
+
+	registry_by_namespace[namespace] = entry
+	registry_by_type[type_of] = entry
+
+	return nil
+}
+
+// RegisterCode is like RegisterCodeFunc but for code types whose underlying
+// type is int. Every integer value is accepted.
+//
+// Parameters:
+//   - namespace: The namespace of the code type.
+//
+// Returns:
+//   - error: An error if the registration failed.
+func RegisterCode[C interface {
+	~int
+	ErrorCoder
+}](namespace string) error {
+	return RegisterCodeFunc(namespace, func(value int) (C, bool) {
+		return C(value), true
+	})
+}
+
+// CodeNamespace returns the namespace of the code.
+//
+// Parameters:
+//   - code: The code to look up.
+//
+// Returns:
+//   - string: The namespace of the code.
+//   - bool: True if the namespace is known, false otherwise.
+//
+// The namespace declared by the code (see Namespacer) takes precedence over
+// the one its type is registered under.
+func CodeNamespace(code ErrorCoder) (string, bool) {
+	if code == nil {
+		return "", false
+	}
+
+	if n, ok := code.(Namespacer); ok {
+		namespace := n.Namespace()
+		if namespace != "" {
+			return namespace, true
+		}
+	}
+
+	registry_mu.RLock()
+	defer registry_mu.RUnlock()
+
+	entry, ok := registry_by_type[reflect.TypeOf(code)]
+	if !ok {
+		return "", false
+	}
+
+	return entry.namespace, true
+}
+
+// LookupCode reconstructs a code from its namespace and integer value.
+//
+// Parameters:
+//   - namespace: The namespace of the code.
+//   - value: The integer value of the code.
+//
+// Returns:
+//   - ErrorCoder: The code. Nil if not found.
+//   - bool: True if the namespace is registered and the value is valid,
+//     false otherwise.
+func LookupCode(namespace string, value int) (ErrorCoder, bool) {
+	registry_mu.RLock()
+	entry, ok := registry_by_namespace[namespace]
+	registry_mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	return entry.decode(value)
+}
+
+// UnknownCode is the code used when decoding an error whose code belongs to
+// a namespace that is not registered.
+type UnknownCode struct {
+	// NS is the namespace of the code.
+	NS string
+
+	// Name is the name of the code.
+	Name string
+
+	// Value is the integer value of the code.
+	Value int
+}
+
+// Namespace implements the Namespacer interface.
+func (c UnknownCode) Namespace() string {
+	return c.NS
+}
+
+// Int implements the ErrorCoder interface.
+func (c UnknownCode) Int() int {
+	return c.Value
+}
+
+// String implements the fmt.Stringer interface.
+func (c UnknownCode) String() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	return "UnknownCode(" + strconv.Itoa(c.Value) + ")"
+}
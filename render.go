@@ -0,0 +1,259 @@
+package errors
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// ansi_reset resets all ANSI attributes.
+	ansi_reset string = "\x1b[0m"
+
+	// ansi_bold sets the bold attribute.
+	ansi_bold string = "\x1b[1m"
+
+	// ansi_blue sets the blue foreground colour.
+	ansi_blue string = "\x1b[34m"
+)
+
+// severity_colors are the ANSI colours of each severity level.
+var severity_colors = map[SeverityLevel]string{
+	INFO:    "\x1b[36m",
+	WARNING: "\x1b[33m",
+	ERROR:   "\x1b[31m",
+	FATAL:   "\x1b[1;31m",
+}
+
+// paint wraps the text with the given ANSI attributes. Does nothing if
+// color is false or attrs is empty.
+//
+// Parameters:
+//   - text: The text to paint.
+//   - attrs: The ANSI attributes.
+//   - color: Whether to paint the text.
+//
+// Returns:
+//   - string: The painted text.
+func paint(text, attrs string, color bool) string {
+	if !color || attrs == "" || text == "" {
+		return text
+	}
+
+	return attrs + text + ansi_reset
+}
+
+// ColorMode is the mode that decides whether ANSI colours are used.
+type ColorMode int
+
+const (
+	// ColorAuto uses colours only if the writer is a terminal and neither
+	// the NO_COLOR environment variable is set nor TERM is "dumb".
+	ColorAuto ColorMode = iota
+
+	// ColorAlways always uses colours.
+	ColorAlways
+
+	// ColorNever never uses colours. Useful for tests.
+	ColorNever
+)
+
+// default_width is the width used for terminals whose width is unknown.
+const default_width int = 80
+
+// is_terminal checks whether the writer is a terminal.
+//
+// Parameters:
+//   - w: The writer to check.
+//
+// Returns:
+//   - bool: True if the writer is a file whose mode is a character device,
+//     false otherwise.
+func is_terminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || f == nil {
+		return false
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// TextRenderer renders errors as human-readable text.
+//
+// The zero value uses colours and wraps lines only when writing to a
+// terminal, and omits stack traces.
+type TextRenderer struct {
+	// Verbose adds the stack traces to the output.
+	Verbose bool
+
+	// Color decides whether ANSI colours are used.
+	Color ColorMode
+
+	// Width is the maximum width of the lines. If 0, lines are wrapped at the
+	// width of the terminal (the COLUMNS environment variable or 80) when
+	// writing to a terminal and are not wrapped otherwise. If negative,
+	// lines are never wrapped.
+	Width int
+
+	// MaxDepth is the maximum number of errors of the chain to display (see
+	// LimitErrorMsg). If less than or equal to 0, the depth is not limited.
+	MaxDepth int
+
+	// MaxBytes is the maximum number of bytes of each message (see
+	// LimitErrorMsg). If less than or equal to 0, the messages are not
+	// limited.
+	MaxBytes int
+}
+
+// text_style is the resolved style of a TextRenderer for a given writer.
+type text_style struct {
+	// color is true if ANSI colours are used.
+	color bool
+
+	// width is the maximum width of the lines. 0 if lines are not wrapped.
+	width int
+
+	// verbose is true if stack traces are displayed.
+	verbose bool
+}
+
+// style resolves the style of the renderer for the given writer.
+//
+// Parameters:
+//   - w: The writer to render to.
+//
+// Returns:
+//   - text_style: The resolved style.
+func (r TextRenderer) style(w io.Writer) text_style {
+	st := text_style{
+		verbose: r.Verbose,
+	}
+
+	terminal := is_terminal(w)
+
+	switch r.Color {
+	case ColorAlways:
+		st.color = true
+	case ColorAuto:
+		st.color = terminal && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	}
+
+	switch {
+	case r.Width > 0:
+		st.width = r.Width
+	case r.Width == 0 && terminal:
+		st.width = default_width
+
+		columns, err := strconv.Atoi(os.Getenv("COLUMNS"))
+		if err == nil && columns > 0 {
+			st.width = columns
+		}
+	}
+
+	return st
+}
+
+// Render implements the Renderer interface.
+func (r TextRenderer) Render(w io.Writer, to_display error) error {
+	if to_display == nil {
+		return nil
+	} else if w == nil {
+		return io.ErrShortWrite
+	}
+
+	if r.MaxDepth > 0 || r.MaxBytes > 0 {
+		to_display = LimitErrorMsg(to_display, r.MaxDepth, r.MaxBytes)
+	}
+
+	var b strings.Builder
+
+	err := display_error(&b, to_display, r.style(w))
+	if err != nil {
+		return err
+	}
+
+	return write_all(w, []byte(b.String()))
+}
+
+// write_all writes the data to the writer.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - data: The data to write.
+//
+// Returns:
+//   - error: io.ErrShortWrite if not all the data was written, or the error
+//     returned by the writer.
+func write_all(w io.Writer, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	} else if w == nil {
+		return io.ErrShortWrite
+	}
+
+	n, err := w.Write(data)
+	if err != nil {
+		return err
+	} else if n != len(data) {
+		return io.ErrShortWrite
+	}
+
+	return nil
+}
+
+// wrap_text wraps each line of the text at the given width, breaking at
+// spaces.
+//
+// Parameters:
+//   - text: The text to wrap.
+//   - width: The maximum width of the lines. If less than or equal to 0,
+//     the text is returned as-is.
+//   - indent: The prefix of the continuation lines.
+//
+// Returns:
+//   - string: The wrapped text.
+//
+// Words longer than the width are not split.
+func wrap_text(text string, width int, indent string) string {
+	if width <= 0 {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) <= width {
+			continue
+		}
+
+		var b strings.Builder
+
+		var size int
+
+		for j, word := range strings.Split(line, " ") {
+			word_size := utf8.RuneCountInString(word)
+
+			if j > 0 && size+1+word_size > width {
+				b.WriteString("\n" + indent)
+				size = utf8.RuneCountInString(indent)
+			} else if j > 0 {
+				b.WriteByte(' ')
+				size++
+			}
+
+			b.WriteString(word)
+			size += word_size
+		}
+
+		lines[i] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
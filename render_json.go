@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONRenderer renders errors as JSON documents, in the same format as
+// Err.MarshalJSON, followed by a newline.
+type JSONRenderer struct {
+	// Indent is the indentation of the document. If empty, the document is
+	// written on a single line.
+	Indent string
+
+	// MaxDepth is the maximum number of errors of the chain to encode (see
+	// LimitErrorMsg). If less than or equal to 0, the depth is not limited.
+	MaxDepth int
+
+	// MaxBytes is the maximum number of bytes of each message (see
+	// LimitErrorMsg). If less than or equal to 0, the messages are not
+	// limited.
+	MaxBytes int
+}
+
+// Render implements the Renderer interface.
+func (r JSONRenderer) Render(w io.Writer, to_display error) error {
+	if to_display == nil {
+		return nil
+	} else if w == nil {
+		return io.ErrShortWrite
+	}
+
+	if r.MaxDepth > 0 || r.MaxBytes > 0 {
+		to_display = LimitErrorMsg(to_display, r.MaxDepth, r.MaxBytes)
+	}
+
+	var data []byte
+	var err error
+
+	if r.Indent == "" {
+		data, err = json.Marshal(to_json_err(to_display))
+	} else {
+		data, err = json.MarshalIndent(to_json_err(to_display), "", r.Indent)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return write_all(w, append(data, '\n'))
+}
@@ -0,0 +1,135 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LogfmtRenderer renders errors as a single logfmt line.
+//
+// Format:
+//
+//	severity=ERROR code=NoSuchKey message="..." ctx.<key>=<value> suggestion="..." cause="..."
+//
+// Each cause of the chain is added as its own cause key, from the outermost
+// to the innermost.
+type LogfmtRenderer struct{}
+
+// logfmt_value quotes the value if needed.
+//
+// Parameters:
+//   - value: The value to quote.
+//
+// Returns:
+//   - string: The value, quoted if it is empty or contains spaces, quotes,
+//     equal signs or control characters.
+func logfmt_value(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	needs_quote := strings.ContainsFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == 0x7f
+	})
+
+	if needs_quote {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// logfmt_key replaces the characters that are not allowed in logfmt keys
+// with underscores.
+//
+// Parameters:
+//   - key: The key to sanitize.
+//
+// Returns:
+//   - string: The sanitized key.
+func logfmt_key(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
+
+// logfmt_pairs writes the key/value pairs of the error into the builder.
+//
+// Parameters:
+//   - b: The builder to write into.
+//   - err: The error. Assumed to be non-nil.
+//   - prefix: The prefix of the keys.
+func logfmt_pairs(b *strings.Builder, err error, prefix string) {
+	pair := func(key, value string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(prefix + key + "=" + logfmt_value(value))
+	}
+
+	e, ok := err.(*Err)
+	if !ok || e == nil {
+		pair("message", err.Error())
+		return
+	}
+
+	pair("severity", e.Severity.String())
+
+	if e.Code != nil {
+		pair("code", CodeID(e.Code))
+	}
+
+	pair("message", e.RenderMessage())
+
+	if e.Info == nil {
+		return
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(e.Info.Context)) {
+		pair("ctx."+logfmt_key(key), fmt.Sprint(e.Info.Context[key]))
+	}
+
+	for _, suggestion := range e.Info.Suggestions {
+		pair("suggestion", suggestion)
+	}
+}
+
+// Render implements the Renderer interface.
+func (r LogfmtRenderer) Render(w io.Writer, to_display error) error {
+	if to_display == nil {
+		return nil
+	} else if w == nil {
+		return io.ErrShortWrite
+	}
+
+	var b strings.Builder
+
+	logfmt_pairs(&b, to_display, "")
+
+	e, ok := to_display.(*Err)
+	if ok && e != nil {
+		for cause := e.Unwrap(); cause != nil; {
+			b.WriteString(" cause=" + logfmt_value(cause.Error()))
+
+			x, ok := cause.(interface{ Unwrap() error })
+			if !ok {
+				break
+			}
+
+			cause = x.Unwrap()
+		}
+	}
+
+	b.WriteByte('\n')
+
+	return write_all(w, []byte(b.String()))
+}
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MarkdownRenderer renders errors as Markdown documents. Each cause is
+// rendered as a sub-section of the error that wraps it.
+type MarkdownRenderer struct {
+	// Level is the heading level of the outermost error. If less than or
+	// equal to 0, 3 is used.
+	Level int
+}
+
+// markdown_escape escapes the characters that have a meaning in Markdown
+// tables and inline text.
+var markdown_escape = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"\n", " ",
+)
+
+// write_markdown writes the error into the builder.
+//
+// Parameters:
+//   - b: The builder to write into.
+//   - err: The error. Assumed to be non-nil.
+//   - level: The heading level of the error.
+func write_markdown(b *strings.Builder, err error, level int) {
+	heading := strings.Repeat("#", min(level, 6))
+
+	e, ok := err.(*Err)
+	if !ok || e == nil {
+		fmt.Fprintf(b, "%s Error\n\n%s\n", heading, markdown_escape.Replace(err.Error()))
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		if ok {
+			for _, sub := range joined.Unwrap() {
+				if sub != nil {
+					b.WriteByte('\n')
+					write_markdown(b, sub, level+1)
+				}
+			}
+		}
+
+		return
+	}
+
+	code := "no code"
+	if e.Code != nil {
+		code = code_label(e.Code)
+	}
+
+	msg := e.RenderMessage()
+	if msg == "" {
+		msg = "[no message was provided]"
+	}
+
+	fmt.Fprintf(b, "%s %v: %s\n\n%s\n", heading, e.Severity, markdown_escape.Replace(code), markdown_escape.Replace(msg))
+
+	info := e.Info
+	if info == nil {
+		return
+	}
+
+	if len(info.Suggestions) > 0 {
+		b.WriteString("\n**Suggestions**\n\n")
+
+		for _, suggestion := range info.Suggestions {
+			fmt.Fprintf(b, "- %s\n", markdown_escape.Replace(suggestion))
+		}
+	}
+
+	if len(info.Context) > 0 {
+		b.WriteString("\n**Context**\n\n| Key | Value |\n| --- | --- |\n")
+
+		for _, key := range slices.Sorted(maps.Keys(info.Context)) {
+			fmt.Fprintf(b, "| %s | %s |\n", markdown_escape.Replace(key), markdown_escape.Replace(fmt.Sprint(info.Context[key])))
+		}
+	}
+
+	if len(info.Frames) > 0 || len(info.Callers) > 0 {
+		b.WriteString("\n**Stack trace**\n\n```\n")
+
+		for i := len(info.Frames) - 1; i >= 0; i-- {
+			b.WriteString(info.Frames[i] + "\n")
+		}
+
+		for _, frame := range resolve_frames(info.Callers) {
+			b.WriteString(frame.String() + "\n")
+		}
+
+		b.WriteString("```\n")
+	}
+
+	if info.Inner != nil {
+		b.WriteString("\n")
+		write_markdown(b, info.Inner, level+1)
+	}
+}
+
+// Render implements the Renderer interface.
+func (r MarkdownRenderer) Render(w io.Writer, to_display error) error {
+	if to_display == nil {
+		return nil
+	} else if w == nil {
+		return io.ErrShortWrite
+	}
+
+	level := r.Level
+	if level <= 0 {
+		level = 3
+	}
+
+	var b strings.Builder
+
+	write_markdown(&b, to_display, level)
+
+	return write_all(w, []byte(b.String()))
+}
@@ -0,0 +1,233 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// new_deep_err creates a chain of depth errors whose messages are long.
+func new_deep_err(depth int) *Err {
+	err := New(BadParameter, "root cause with a long message")
+
+	for i := 1; i < depth; i++ {
+		outer := New(OperationFail, fmt.Sprintf("level %d with a long message", i))
+		outer.SetInner(err)
+
+		err = outer
+	}
+
+	return err
+}
+
+func TestTextRendererLimits(t *testing.T) {
+	disable_stack_capture(t)
+
+	var buf bytes.Buffer
+
+	r := TextRenderer{Color: ColorNever, MaxDepth: 2, MaxBytes: 10}
+
+	err := r.Render(&buf, new_deep_err(6))
+	if err != nil {
+		t.Fatalf("Render() = %v", err)
+	}
+
+	want := "[ERROR] OperationFail: level 5 wi...\n" +
+		"\n" +
+		"Caused by:\n" +
+		"[ERROR] OperationFail: level 4 wi...\n" +
+		"\n" +
+		"Caused by:\n" +
+		"...4 more cause(s)\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJSONRendererLimits(t *testing.T) {
+	disable_stack_capture(t)
+
+	var buf bytes.Buffer
+
+	r := JSONRenderer{MaxDepth: 2, MaxBytes: 10}
+
+	err := r.Render(&buf, new_deep_err(6))
+	if err != nil {
+		t.Fatalf("Render() = %v", err)
+	}
+
+	want := `{"severity":"ERROR","code":{"namespace":"errors","name":"OperationFail","value":3},"message":"level 5 wi...",` +
+		`"cause":{"severity":"ERROR","code":{"namespace":"errors","name":"OperationFail","value":3},"message":"level 4 wi...",` +
+		`"cause":{"message":"...4 more cause(s)"}}}` + "\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRendererNoLimits(t *testing.T) {
+	disable_stack_capture(t)
+
+	var buf bytes.Buffer
+
+	err := TextRenderer{Color: ColorNever}.Render(&buf, new_deep_err(6))
+	if err != nil {
+		t.Fatalf("Render() = %v", err)
+	}
+
+	if n := bytes.Count(buf.Bytes(), []byte("Caused by:")); n != 5 {
+		t.Errorf("Render() displayed %d causes, want 5", n)
+	}
+}
+
+func TestDefaultRendererOmitsStackTraces(t *testing.T) {
+	err := New(OperationFail, "boom")
+
+	var buf bytes.Buffer
+
+	d_err := DisplayError(&buf, err)
+	if d_err != nil {
+		t.Fatalf("DisplayError() = %v", d_err)
+	}
+
+	if got, want := buf.String(), "[ERROR] OperationFail: boom\n"; got != want {
+		t.Errorf("DisplayError() = %q, want %q", got, want)
+	}
+
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "Stack trace:") {
+		t.Errorf("%%+v = %q, want a stack trace", got)
+	}
+
+	SetDefaultRenderer(JSONRenderer{})
+	SetDefaultRenderer(nil)
+
+	if r, ok := DefaultRenderer().(TextRenderer); !ok || r.Verbose {
+		t.Errorf("DefaultRenderer() after SetDefaultRenderer(nil) = %#v, want TextRenderer{}", DefaultRenderer())
+	}
+}
+
+// open_terminal opens a character device that is_terminal reports as a
+// terminal. The test is skipped if none is available.
+func open_terminal(t *testing.T) *os.File {
+	t.Helper()
+
+	f, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("cannot open %s: %v", os.DevNull, err)
+	}
+
+	t.Cleanup(func() { f.Close() })
+
+	if !is_terminal(f) {
+		t.Skipf("%s is not a character device", os.DevNull)
+	}
+
+	return f
+}
+
+func TestTextRendererStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer TextRenderer
+		terminal bool
+		env      map[string]string
+		want     text_style
+	}{
+		{"auto buffer", TextRenderer{}, false, nil, text_style{}},
+		{"auto terminal", TextRenderer{}, true, nil, text_style{color: true, width: default_width}},
+		{"auto NO_COLOR", TextRenderer{}, true, map[string]string{"NO_COLOR": "1"}, text_style{width: default_width}},
+		{"auto dumb terminal", TextRenderer{}, true, map[string]string{"TERM": "dumb"}, text_style{width: default_width}},
+		{"always buffer", TextRenderer{Color: ColorAlways}, false, nil, text_style{color: true}},
+		{"always NO_COLOR", TextRenderer{Color: ColorAlways}, true, map[string]string{"NO_COLOR": "1"}, text_style{color: true, width: default_width}},
+		{"never terminal", TextRenderer{Color: ColorNever}, true, nil, text_style{width: default_width}},
+		{"COLUMNS", TextRenderer{Color: ColorNever}, true, map[string]string{"COLUMNS": "120"}, text_style{width: 120}},
+		{"invalid COLUMNS", TextRenderer{Color: ColorNever}, true, map[string]string{"COLUMNS": "wide"}, text_style{width: default_width}},
+		{"negative COLUMNS", TextRenderer{Color: ColorNever}, true, map[string]string{"COLUMNS": "-5"}, text_style{width: default_width}},
+		{"COLUMNS buffer", TextRenderer{}, false, map[string]string{"COLUMNS": "120"}, text_style{}},
+		{"explicit width", TextRenderer{Width: 40}, false, map[string]string{"COLUMNS": "120"}, text_style{width: 40}},
+		{"negative width", TextRenderer{Color: ColorNever, Width: -1}, true, nil, text_style{}},
+		{"verbose", TextRenderer{Verbose: true}, false, nil, text_style{verbose: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"NO_COLOR", "TERM", "COLUMNS"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			var w io.Writer = &bytes.Buffer{}
+			if tt.terminal {
+				w = open_terminal(t)
+			}
+
+			if got := tt.renderer.style(w); got != tt.want {
+				t.Errorf("style() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextRendererColor(t *testing.T) {
+	err := NewWithSeverity(WARNING, BadParameter, "bad")
+
+	tests := []struct {
+		name  string
+		color ColorMode
+		want  bool
+	}{
+		{"always", ColorAlways, true},
+		{"never", ColorNever, false},
+		{"auto", ColorAuto, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			r_err := TextRenderer{Color: tt.color}.Render(&buf, err)
+			if r_err != nil {
+				t.Fatalf("Render() = %v", r_err)
+			}
+
+			if got := strings.Contains(buf.String(), "\x1b["); got != tt.want {
+				t.Errorf("Render() = %q, colours = %t, want %t", buf.String(), got, tt.want)
+			}
+
+			if !strings.Contains(buf.String(), "bad") {
+				t.Errorf("Render() = %q, want it to contain the message", buf.String())
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		width  int
+		indent string
+		want   string
+	}{
+		{"no width", "a b c", 0, "", "a b c"},
+		{"negative width", "a b c", -1, "", "a b c"},
+		{"fits", "a b c", 5, "", "a b c"},
+		{"wrapped", "aaa bbb ccc", 7, "", "aaa bbb\nccc"},
+		{"indent", "aaa bbb ccc", 7, "  ", "aaa bbb\n  ccc"},
+		{"indent counts", "aaa bbb ccc ddd", 8, "    ", "aaa bbb\n    ccc\n    ddd"},
+		{"long word", "a verylongword b", 5, "", "a\nverylongword\nb"},
+		{"lines", "aaa bbb\nccc ddd eee", 7, "", "aaa bbb\nccc ddd\neee"},
+		{"runes", "ééé ééé", 7, "", "ééé ééé"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrap_text(tt.text, tt.width, tt.indent); got != tt.want {
+				t.Errorf("wrap_text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package errors
+
+import (
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Renderer renders errors to a writer.
+type Renderer interface {
+	// Render writes the complete error to the writer.
+	//
+	// Parameters:
+	//   - w: The writer to write to.
+	//   - to_display: The error to render.
+	//
+	// Returns:
+	//   - error: The error that occurred while rendering the error.
+	Render(w io.Writer, to_display error) error
+}
+
+var (
+	// renderers_mu protects renderers and default_renderer.
+	renderers_mu sync.RWMutex
+
+	// renderers are the registered renderers by name.
+	renderers = map[string]Renderer{
+		"text":     TextRenderer{},
+		"verbose":  TextRenderer{Verbose: true},
+		"json":     JSONRenderer{},
+		"logfmt":   LogfmtRenderer{},
+		"markdown": MarkdownRenderer{},
+	}
+
+	// default_renderer is the renderer used by DisplayError and Panic.
+	default_renderer Renderer = TextRenderer{}
+)
+
+// RegisterRenderer registers a renderer under the given name.
+//
+// Parameters:
+//   - name: The name of the renderer.
+//   - r: The renderer.
+//
+// Returns:
+//   - error: An error if the registration failed.
+//
+// Errors:
+//   - *Err with code BadParameter: If the name is empty or r is nil.
+//   - *Err with code InvalidUsage: If the name is already registered.
+//
+// The built-in renderers are "text", "verbose", "json", "logfmt" and
+// "markdown".
+func RegisterRenderer(name string, r Renderer) error {
+	if name == "" {
+		return NewErrInvalidParameter("RegisterRenderer()", "name must not be empty")
+	} else if r == nil {
+		return NewErrNilParameter("RegisterRenderer()", "r")
+	}
+
+	renderers_mu.Lock()
+	defer renderers_mu.Unlock()
+
+	if _, ok := renderers[name]; ok {
+		return NewErrInvalidUsage("RegisterRenderer()", "renderer ("+strconv.Quote(name)+") is already registered", "Use a different name for each renderer")
+	}
+
+	renderers[name] = r
+
+	return nil
+}
+
+// LookupRenderer returns the renderer registered under the given name.
+//
+// Parameters:
+//   - name: The name of the renderer.
+//
+// Returns:
+//   - Renderer: The renderer. Nil if not found.
+//   - bool: True if the renderer was found, false otherwise.
+func LookupRenderer(name string) (Renderer, bool) {
+	renderers_mu.RLock()
+	defer renderers_mu.RUnlock()
+
+	r, ok := renderers[name]
+	return r, ok
+}
+
+// RendererNames returns the names of the registered renderers.
+//
+// Returns:
+//   - []string: The names, sorted in ascending order.
+func RendererNames() []string {
+	renderers_mu.RLock()
+	defer renderers_mu.RUnlock()
+
+	names := make([]string, 0, len(renderers))
+
+	for name := range renderers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// SetDefaultRenderer sets the renderer used by DisplayError and Panic.
+//
+// Parameters:
+//   - r: The renderer. If nil, the default "text" renderer is restored.
+func SetDefaultRenderer(r Renderer) {
+	if r == nil {
+		r = TextRenderer{}
+	}
+
+	renderers_mu.Lock()
+	defer renderers_mu.Unlock()
+
+	default_renderer = r
+}
+
+// DefaultRenderer returns the renderer used by DisplayError and Panic.
+//
+// Returns:
+//   - Renderer: The default renderer. Never returns nil.
+func DefaultRenderer() Renderer {
+	renderers_mu.RLock()
+	defer renderers_mu.RUnlock()
+
+	return default_renderer
+}
+
+// DisplayErrorWith is like DisplayError but uses the given renderer.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - to_display: The error to display.
+//   - r: The renderer to use. If nil, the default renderer is used.
+//
+// Returns:
+//   - error: The error that occurred while displaying the error.
+func DisplayErrorWith(w io.Writer, to_display error, r Renderer) error {
+	if r == nil {
+		r = DefaultRenderer()
+	}
+
+	return r.Render(w, to_display)
+}
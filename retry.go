@@ -0,0 +1,383 @@
+package errors
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// Retryability is the retry classification of an error.
+type Retryability int
+
+const (
+	// RetryUnknown means that the error does not say whether retrying
+	// may succeed.
+	RetryUnknown Retryability = iota
+
+	// RetryTransient means that the failure is temporary and the operation
+	// may succeed if retried.
+	RetryTransient
+
+	// RetryPermanent means that retrying the operation will not succeed.
+	RetryPermanent
+)
+
+// Transienter is implemented by error codes that classify every error
+// with that code as either transient or permanent.
+type Transienter interface {
+	// Transient returns true if errors with this code are transient, false
+	// if they are permanent.
+	//
+	// Returns:
+	//   - bool: True if errors with this code are transient.
+	Transient() bool
+}
+
+// MarkTransient marks the error as transient. Does nothing if the receiver
+// is nil.
+//
+// Parameters:
+//   - after: The minimum delay before retrying. 0 to let the retrier decide.
+func (e *Err) MarkTransient(after time.Duration) {
+	if e == nil {
+		return
+	}
+
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	e.Info.Retryability = int(RetryTransient)
+	e.Info.RetryAfter = max(after, 0)
+}
+
+// MarkPermanent marks the error as permanent. Does nothing if the receiver
+// is nil.
+func (e *Err) MarkPermanent() {
+	if e == nil {
+		return
+	}
+
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	e.Info.Retryability = int(RetryPermanent)
+	e.Info.RetryAfter = 0
+}
+
+// classify_one classifies a single error without looking at its causes.
+//
+// Parameters:
+//   - err: The error to classify.
+//
+// Returns:
+//   - Retryability: The classification of the error.
+//   - time.Duration: The minimum delay before retrying.
+func classify_one(err error) (Retryability, time.Duration) {
+	switch x := err.(type) {
+	case *Err:
+		if x == nil {
+			return RetryUnknown, 0
+		}
+
+		if x.Info != nil && x.Info.Retryability != int(RetryUnknown) {
+			return Retryability(x.Info.Retryability), x.Info.RetryAfter
+		}
+
+		if t, ok := x.Code.(Transienter); ok {
+			if t.Transient() {
+				return RetryTransient, 0
+			}
+
+			return RetryPermanent, 0
+		}
+	default:
+		if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+			return RetryTransient, 0
+		}
+
+		if t, ok := err.(interface{ Temporary() bool }); ok && t.Temporary() {
+			return RetryTransient, 0
+		}
+	}
+
+	return RetryUnknown, 0
+}
+
+// Classify returns the retry classification of an error.
+//
+// Parameters:
+//   - err: The error to classify.
+//
+// Returns:
+//   - Retryability: The classification of the error. RetryUnknown if err
+//     is nil.
+//   - time.Duration: The minimum delay before retrying. 0 if unspecified.
+//
+// The chain is walked from the outermost error and the first error that
+// is classified decides. Errors marked with MarkTransient or MarkPermanent
+// take precedence over their code (see Transienter), and errors that are
+// not *Err are transient if their Timeout or Temporary method returns true.
+// When an error wraps many causes, it is permanent if any of them is,
+// transient if any of them is and unknown otherwise.
+func Classify(err error) (Retryability, time.Duration) {
+	for err != nil {
+		class, after := classify_one(err)
+		if class != RetryUnknown {
+			return class, after
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			var longest time.Duration
+
+			class := RetryUnknown
+
+			for _, sub := range x.Unwrap() {
+				sub_class, sub_after := Classify(sub)
+
+				switch sub_class {
+				case RetryPermanent:
+					return RetryPermanent, 0
+				case RetryTransient:
+					class = RetryTransient
+					longest = max(longest, sub_after)
+				}
+			}
+
+			return class, longest
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		default:
+			return RetryUnknown, 0
+		}
+	}
+
+	return RetryUnknown, 0
+}
+
+// IsTransient checks whether the error is transient.
+//
+// Parameters:
+//   - err: The error to check.
+//
+// Returns:
+//   - bool: True if the error is transient, false otherwise.
+func IsTransient(err error) bool {
+	class, _ := Classify(err)
+	return class == RetryTransient
+}
+
+// Clock is the source of time used by Retrier.
+type Clock interface {
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	//
+	// Parameters:
+	//   - d: The duration to wait.
+	//
+	// Returns:
+	//   - <-chan time.Time: The channel the time is sent on.
+	After(d time.Duration) <-chan time.Time
+}
+
+// system_clock is the Clock backed by the time package.
+type system_clock struct{}
+
+// After implements the Clock interface.
+func (system_clock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Retrier retries operations that fail with transient errors, waiting with
+// an exponential backoff between attempts. The zero value is ready to use.
+type Retrier struct {
+	// MaxAttempts is the maximum number of attempts. Defaults to 3 if not
+	// positive.
+	MaxAttempts int
+
+	// Initial is the delay before the second attempt. Defaults to 100ms if
+	// not positive.
+	Initial time.Duration
+
+	// Max is the maximum delay between attempts. 0 for no maximum. It does
+	// not apply to the delay requested by the error itself.
+	Max time.Duration
+
+	// Multiplier is the factor the delay grows by after each attempt.
+	// Defaults to 2 if less than 1.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, of each delay that is
+	// randomly removed.
+	Jitter float64
+
+	// RetryUnknown is true if errors whose classification is RetryUnknown
+	// are retried too.
+	RetryUnknown bool
+
+	// Clock is the source of time. Defaults to the system clock if nil.
+	Clock Clock
+
+	// Rand returns a random number in [0, 1) used for the jitter. Defaults
+	// to math/rand/v2.Float64 if nil.
+	Rand func() float64
+}
+
+// delay returns the delay before the next attempt.
+//
+// Parameters:
+//   - attempt: The number of attempts done so far. Assumed to be positive.
+//   - after: The minimum delay requested by the error.
+//
+// Returns:
+//   - time.Duration: The delay.
+func (r *Retrier) delay(attempt int, after time.Duration) time.Duration {
+	d := float64(r.Initial)
+	if d <= 0 {
+		d = float64(100 * time.Millisecond)
+	}
+
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	for i := 1; i < attempt; i++ {
+		d *= multiplier
+
+		if r.Max > 0 && d >= float64(r.Max) {
+			break
+		}
+	}
+
+	if r.Max > 0 {
+		d = min(d, float64(r.Max))
+	}
+
+	// Keep the delay representable as a time.Duration.
+	d = min(d, float64(math.MaxInt64/2))
+
+	jitter := min(max(r.Jitter, 0), 1)
+	if jitter > 0 {
+		rand_fn := r.Rand
+		if rand_fn == nil {
+			rand_fn = rand.Float64
+		}
+
+		d -= d * jitter * rand_fn()
+	}
+
+	return max(time.Duration(d), after)
+}
+
+// Retry calls fn until it succeeds, fails with an error that should not be
+// retried, the attempts are exhausted or ctx is done.
+//
+// Parameters:
+//   - ctx: The context of the operation. If nil, context.Background() is
+//     used.
+//   - fn: The operation to retry. It receives ctx.
+//
+// Returns:
+//   - error: Nil if an attempt succeeded. Otherwise, an *Err with code
+//     OperationFail that wraps an *Aggregate of the error of every attempt,
+//     followed by the error of ctx (see FromContext) if it is done.
+//
+// The returned error is marked as permanent so that nested retriers do not
+// multiply the attempts.
+//
+// The delay before each retry is the larger of the backoff delay and the
+// delay requested by the error (see MarkTransient).
+func (r *Retrier) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return NewErrNilParameter("Retrier.Retry()", "fn")
+	}
+
+	if r == nil {
+		r = &Retrier{}
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	max_attempts := r.MaxAttempts
+	if max_attempts <= 0 {
+		max_attempts = 3
+	}
+
+	clock := r.Clock
+	if clock == nil {
+		clock = system_clock{}
+	}
+
+	attempts := NewAggregate()
+
+	var count int
+
+	for {
+		if ctx.Err() != nil {
+			attempts.Add(FromContext(ctx))
+			break
+		}
+
+		count++
+
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		attempts.Add(err)
+
+		class, after := Classify(err)
+		if class == RetryPermanent || (class == RetryUnknown && !r.RetryUnknown) || count >= max_attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			attempts.Add(FromContext(ctx))
+		case <-clock.After(r.delay(count, after)):
+			continue
+		}
+
+		break
+	}
+
+	var message string
+
+	if count == 1 {
+		message = "gave up after 1 attempt"
+	} else {
+		message = "gave up after " + strconv.Itoa(count) + " attempts"
+	}
+
+	err := New(OperationFail, message)
+	err.AddContext("attempts", count)
+	err.SetInner(attempts)
+	err.MarkPermanent()
+
+	return err
+}
+
+// Retry is like Retrier.Retry but with the default settings.
+//
+// Parameters:
+//   - ctx: The context of the operation. If nil, context.Background() is
+//     used.
+//   - fn: The operation to retry. It receives ctx.
+//
+// Returns:
+//   - error: Nil if an attempt succeeded. Otherwise, an *Err with code
+//     OperationFail that wraps the error of every attempt.
+func Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	var r Retrier
+	return r.Retry(ctx, fn)
+}
@@ -0,0 +1,311 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fake_clock is a Clock that records the requested delays and fires
+// immediately.
+type fake_clock struct {
+	// waits are the requested delays.
+	waits []time.Duration
+
+	// on_after is called on each call to After, if not nil.
+	on_after func()
+
+	// block is true if the returned channels never fire.
+	block bool
+}
+
+// After implements the Clock interface.
+func (c *fake_clock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+
+	if c.on_after != nil {
+		c.on_after()
+	}
+
+	ch := make(chan time.Time, 1)
+
+	if !c.block {
+		ch <- time.Time{}
+	}
+
+	return ch
+}
+
+// transient_fn returns an operation that always fails with a transient
+// error whose retry delay is after, and counts its calls.
+func transient_fn(calls *int, after time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		*calls++
+
+		err := New(OperationFail, fmt.Sprintf("attempt %d", *calls))
+		err.MarkTransient(after)
+
+		return err
+	}
+}
+
+func TestRetrierBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		retrier Retrier
+		after   time.Duration
+		want    []time.Duration
+	}{
+		{
+			name:    "defaults",
+			retrier: Retrier{},
+			want:    []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:    "growth",
+			retrier: Retrier{MaxAttempts: 5, Initial: 10 * time.Millisecond, Multiplier: 3},
+			want:    []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 90 * time.Millisecond, 270 * time.Millisecond},
+		},
+		{
+			name:    "max cap",
+			retrier: Retrier{MaxAttempts: 5, Initial: 100 * time.Millisecond, Max: 250 * time.Millisecond},
+			want:    []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond},
+		},
+		{
+			name:    "jitter",
+			retrier: Retrier{MaxAttempts: 3, Initial: 100 * time.Millisecond, Jitter: 0.5, Rand: func() float64 { return 0.5 }},
+			want:    []time.Duration{75 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name:    "retry after floor",
+			retrier: Retrier{MaxAttempts: 3, Initial: 100 * time.Millisecond},
+			after:   time.Second,
+			want:    []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:    "retry after below backoff",
+			retrier: Retrier{MaxAttempts: 4, Initial: 100 * time.Millisecond},
+			after:   150 * time.Millisecond,
+			want:    []time.Duration{150 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+		},
+		{
+			name:    "retry after above max",
+			retrier: Retrier{MaxAttempts: 2, Initial: 100 * time.Millisecond, Max: 200 * time.Millisecond},
+			after:   time.Second,
+			want:    []time.Duration{time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fake_clock{}
+
+			r := tt.retrier
+			r.Clock = clock
+
+			var calls int
+
+			err := r.Retry(context.Background(), transient_fn(&calls, tt.after))
+			if err == nil {
+				t.Fatalf("Retry() = nil, want an error")
+			}
+
+			if !reflect.DeepEqual(clock.waits, tt.want) {
+				t.Errorf("waits = %v, want %v", clock.waits, tt.want)
+			}
+
+			if calls != len(tt.want)+1 {
+				t.Errorf("calls = %d, want %d", calls, len(tt.want)+1)
+			}
+
+			e := err.(*Err)
+
+			if n, _ := e.Value("attempts"); n != calls {
+				t.Errorf("attempts = %v, want %d", n, calls)
+			}
+
+			if class, _ := Classify(e); class != RetryPermanent {
+				t.Errorf("Classify() = %v, want %v", class, RetryPermanent)
+			}
+		})
+	}
+}
+
+func TestRetrierSucceeds(t *testing.T) {
+	clock := &fake_clock{}
+	r := Retrier{MaxAttempts: 5, Clock: clock}
+
+	var calls int
+
+	err := r.Retry(context.Background(), func(ctx context.Context) error {
+		calls++
+
+		if calls < 3 {
+			return transient_fn(new(int), 0)(ctx)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Retry() = %v, want nil", err)
+	}
+
+	if calls != 3 || len(clock.waits) != 2 {
+		t.Errorf("calls = %d, waits = %v, want 3 calls and 2 waits", calls, clock.waits)
+	}
+}
+
+func TestRetrierPermanent(t *testing.T) {
+	permanent := New(BadParameter, "bad")
+	permanent.MarkPermanent()
+
+	tests := []struct {
+		name    string
+		retrier Retrier
+		err     error
+		want    int
+	}{
+		{"permanent", Retrier{MaxAttempts: 5}, permanent, 1},
+		{"unknown", Retrier{MaxAttempts: 5}, fmt.Errorf("plain"), 1},
+		{"unknown retried", Retrier{MaxAttempts: 5, RetryUnknown: true}, fmt.Errorf("plain"), 5},
+		{"permanent with RetryUnknown", Retrier{MaxAttempts: 5, RetryUnknown: true}, permanent, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fake_clock{}
+
+			r := tt.retrier
+			r.Clock = clock
+
+			var calls int
+
+			err := r.Retry(context.Background(), func(ctx context.Context) error {
+				calls++
+				return tt.err
+			})
+
+			if calls != tt.want {
+				t.Errorf("calls = %d, want %d", calls, tt.want)
+			}
+
+			if len(clock.waits) != tt.want-1 {
+				t.Errorf("waits = %v, want %d", clock.waits, tt.want-1)
+			}
+
+			if !Is(err, OperationFail) {
+				t.Errorf("Retry() = %v, want an OperationFail error", err)
+			}
+		})
+	}
+}
+
+func TestRetrierCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &fake_clock{
+		on_after: cancel,
+		block:    true,
+	}
+
+	r := Retrier{MaxAttempts: 5, Clock: clock}
+
+	var calls int
+
+	err := r.Retry(ctx, transient_fn(&calls, 0))
+
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	if !Is(err, Canceled) {
+		t.Errorf("Retry() = %v, want a Canceled cause", err)
+	}
+
+	if n, _ := err.(*Err).Value("attempts"); n != 1 {
+		t.Errorf("attempts = %v, want 1", n)
+	}
+}
+
+func TestRetrierCanceledBeforeFirstAttempt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+
+	err := Retry(ctx, transient_fn(&calls, 0))
+
+	if calls != 0 {
+		t.Errorf("calls = %d, want 0", calls)
+	}
+
+	if !Is(err, Canceled) {
+		t.Errorf("Retry() = %v, want a Canceled cause", err)
+	}
+}
+
+// net_like_err is an error with the Timeout and Temporary methods of
+// net.Error.
+type net_like_err struct {
+	timeout   bool
+	temporary bool
+}
+
+func (e net_like_err) Error() string   { return "network error" }
+func (e net_like_err) Timeout() bool   { return e.timeout }
+func (e net_like_err) Temporary() bool { return e.temporary }
+
+// temporary_err is an error with only the Temporary method.
+type temporary_err struct{}
+
+func (temporary_err) Error() string   { return "temporary" }
+func (temporary_err) Temporary() bool { return true }
+
+// transient_code is a code that declares its errors transient.
+type transient_code int
+
+func (c transient_code) Int() int        { return int(c) }
+func (c transient_code) String() string  { return "transient_code" }
+func (c transient_code) Transient() bool { return c == 1 }
+
+func TestClassify(t *testing.T) {
+	marked := New(BadParameter, "marked")
+	marked.MarkTransient(time.Second)
+
+	overridden := New(transient_code(1), "overridden")
+	overridden.MarkPermanent()
+
+	tests := []struct {
+		name       string
+		err        error
+		want       Retryability
+		want_after time.Duration
+	}{
+		{"nil", nil, RetryUnknown, 0},
+		{"plain", fmt.Errorf("plain"), RetryUnknown, 0},
+		{"marked", marked, RetryTransient, time.Second},
+		{"wrapped marked", fmt.Errorf("wrap: %w", marked), RetryTransient, time.Second},
+		{"transient code", New(transient_code(1), "c"), RetryTransient, 0},
+		{"permanent code", New(transient_code(2), "c"), RetryPermanent, 0},
+		{"mark overrides code", overridden, RetryPermanent, 0},
+		{"timeout", net_like_err{timeout: true}, RetryTransient, 0},
+		{"temporary without timeout", net_like_err{temporary: true}, RetryTransient, 0},
+		{"neither", net_like_err{}, RetryUnknown, 0},
+		{"temporary only", temporary_err{}, RetryTransient, 0},
+		{"joined transient", NewAggregate(fmt.Errorf("plain"), marked), RetryTransient, time.Second},
+		{"joined permanent wins", NewAggregate(marked, New(transient_code(2), "c")), RetryPermanent, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, after := Classify(tt.err)
+			if got != tt.want || after != tt.want_after {
+				t.Errorf("Classify() = (%v, %v), want (%v, %v)", got, after, tt.want, tt.want_after)
+			}
+		})
+	}
+}
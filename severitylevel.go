@@ -1,5 +1,10 @@
 package errors
 
+import (
+	"strconv"
+	"strings"
+)
+
 // SeverityLevel represents the severity level of an error.
 type SeverityLevel int
 
@@ -24,3 +29,78 @@ const (
 	// These are usually panic-level of errors.
 	FATAL
 )
+
+// IsValid checks whether the severity level is one of the defined levels.
+//
+// Returns:
+//   - bool: True if the severity level is valid, false otherwise.
+func (s SeverityLevel) IsValid() bool {
+	return s >= INFO && s <= FATAL
+}
+
+// ParseSeverityLevel parses a severity level.
+//
+// Parameters:
+//   - str: The string to parse. Either the name of the severity level,
+//     case-insensitively, or its integer value.
+//
+// Returns:
+//   - SeverityLevel: The parsed severity level.
+//   - error: An error if the string is not a valid severity level.
+//
+// Errors:
+//   - *Err with code BadParameter: If the string is not a valid severity
+//     level.
+func ParseSeverityLevel(str string) (SeverityLevel, error) {
+	trimmed := strings.TrimSpace(str)
+
+	for level := INFO; level <= FATAL; level++ {
+		if strings.EqualFold(level.String(), trimmed) {
+			return level, nil
+		}
+	}
+
+	value, err := strconv.Atoi(trimmed)
+	if err == nil && SeverityLevel(value).IsValid() {
+		return SeverityLevel(value), nil
+	}
+
+	p_err := NewErrInvalidParameter("ParseSeverityLevel()", "severity level ("+strconv.Quote(str)+") does not exist")
+	p_err.AddSuggestion("Use one of INFO, WARNING, ERROR or FATAL, or their integer values 0 to 3")
+
+	return INFO, p_err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s SeverityLevel) MarshalText() ([]byte, error) {
+	if !s.IsValid() {
+		return nil, NewErrInvalidParameter("SeverityLevel.MarshalText()", "severity level ("+strconv.Itoa(int(s))+") is out of range")
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+//
+// See ParseSeverityLevel for the accepted formats.
+func (s *SeverityLevel) UnmarshalText(text []byte) error {
+	if s == nil {
+		return NewErrNilReceiver("SeverityLevel.UnmarshalText()")
+	}
+
+	level, err := ParseSeverityLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = level
+
+	return nil
+}
+
+// Set implements the flag.Value interface.
+//
+// See ParseSeverityLevel for the accepted formats.
+func (s *SeverityLevel) Set(str string) error {
+	return s.UnmarshalText([]byte(str))
+}
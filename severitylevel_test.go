@@ -0,0 +1,153 @@
+package errors
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseSeverityLevel(t *testing.T) {
+	tests := []struct {
+		str      string
+		want     SeverityLevel
+		want_err bool
+	}{
+		{"INFO", INFO, false},
+		{"warning", WARNING, false},
+		{"Error", ERROR, false},
+		{"  fatal\n", FATAL, false},
+		{"0", INFO, false},
+		{"3", FATAL, false},
+		{" 2 ", ERROR, false},
+		{"4", INFO, true},
+		{"-1", INFO, true},
+		{"", INFO, true},
+		{"WARN", INFO, true},
+		{"1.0", INFO, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			got, err := ParseSeverityLevel(tt.str)
+			if (err != nil) != tt.want_err {
+				t.Fatalf("ParseSeverityLevel() error = %v, want_err %t", err, tt.want_err)
+			}
+
+			if err != nil && !Is(err, BadParameter) {
+				t.Errorf("ParseSeverityLevel() error = %v, want a BadParameter error", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseSeverityLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityLevelText(t *testing.T) {
+	tests := []struct {
+		level    SeverityLevel
+		want     string
+		want_err bool
+	}{
+		{INFO, "INFO", false},
+		{WARNING, "WARNING", false},
+		{ERROR, "ERROR", false},
+		{FATAL, "FATAL", false},
+		{SeverityLevel(4), "", true},
+		{SeverityLevel(-1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			data, err := tt.level.MarshalText()
+			if (err != nil) != tt.want_err {
+				t.Fatalf("MarshalText() error = %v, want_err %t", err, tt.want_err)
+			}
+
+			if string(data) != tt.want {
+				t.Errorf("MarshalText() = %q, want %q", data, tt.want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			var got SeverityLevel
+
+			err = got.UnmarshalText(data)
+			if err != nil {
+				t.Fatalf("UnmarshalText() = %v", err)
+			}
+
+			if got != tt.level {
+				t.Errorf("UnmarshalText() = %v, want %v", got, tt.level)
+			}
+		})
+	}
+}
+
+func TestSeverityLevelUnmarshalTextErrors(t *testing.T) {
+	level := WARNING
+
+	err := level.UnmarshalText([]byte("loud"))
+	if !Is(err, BadParameter) {
+		t.Errorf("UnmarshalText() = %v, want a BadParameter error", err)
+	}
+
+	if level != WARNING {
+		t.Errorf("level = %v after a failed UnmarshalText(), want %v", level, WARNING)
+	}
+
+	var null *SeverityLevel
+
+	if err := null.UnmarshalText([]byte("INFO")); err == nil {
+		t.Errorf("UnmarshalText() on a nil receiver = nil, want an error")
+	}
+}
+
+func TestSeverityLevelFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		want     SeverityLevel
+		want_err bool
+	}{
+		{nil, WARNING, false},
+		{[]string{"-level", "fatal"}, FATAL, false},
+		{[]string{"-level=0"}, INFO, false},
+		{[]string{"-level", "loud"}, WARNING, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt_args(tt.args), func(t *testing.T) {
+			level := WARNING
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&level, "level", "the severity level")
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.want_err {
+				t.Fatalf("Parse() error = %v, want_err %t", err, tt.want_err)
+			}
+
+			if level != tt.want {
+				t.Errorf("level = %v, want %v", level, tt.want)
+			}
+
+			if got := fs.Lookup("level").Value.String(); got != tt.want.String() {
+				t.Errorf("String() = %q, want %q", got, tt.want.String())
+			}
+		})
+	}
+}
+
+// fmt_args returns the name of a subtest for the given flag arguments.
+func fmt_args(args []string) string {
+	if len(args) == 0 {
+		return "default"
+	}
+
+	return strings.Join(args, " ")
+}
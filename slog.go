@@ -0,0 +1,301 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// SlogLevel maps the severity level to a slog.Level.
+//
+// Returns:
+//   - slog.Level: The slog level of the severity level.
+//
+// Mapping:
+//   - INFO: slog.LevelInfo
+//   - WARNING: slog.LevelWarn
+//   - ERROR: slog.LevelError
+//   - FATAL: slog.LevelError + 4
+//
+// Unknown severity levels are mapped to slog.LevelError.
+func (s SeverityLevel) SlogLevel() slog.Level {
+	switch s {
+	case INFO:
+		return slog.LevelInfo
+	case WARNING:
+		return slog.LevelWarn
+	case FATAL:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
+// LogValue implements the slog.LogValuer interface.
+//
+// Returns:
+//   - slog.Value: A group value with the severity, code, message, template
+//     and its arguments, context, suggestions and cause of the error.
+func (e *Err) LogValue() slog.Value {
+	if e == nil {
+		return slog.StringValue("")
+	}
+
+	attrs := []slog.Attr{
+		slog.String("severity", e.Severity.String()),
+	}
+
+	if e.Code != nil {
+		attrs = append(attrs, slog.Group("code",
+			slog.String("id", CodeID(e.Code)),
+			slog.String("name", e.Code.String()),
+			slog.Int("int", e.Code.Int()),
+		))
+	}
+
+	attrs = append(attrs, slog.String("message", e.RenderMessage()))
+
+	if e.Info == nil {
+		return slog.GroupValue(attrs...)
+	}
+
+	if e.Info.Template != "" {
+		attrs = append(attrs, slog.String("template", e.Info.Template))
+	}
+
+	if len(e.Info.TemplateArgs) > 0 {
+		keys := slices.Sorted(maps.Keys(e.Info.TemplateArgs))
+
+		arg_attrs := make([]slog.Attr, 0, len(keys))
+
+		for _, key := range keys {
+			arg_attrs = append(arg_attrs, expand_attr(slog.Any(key, e.Info.TemplateArgs[key])))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "template_args", Value: slog.GroupValue(arg_attrs...)})
+	}
+
+	if len(e.Info.Context) > 0 {
+		keys := slices.Sorted(maps.Keys(e.Info.Context))
+
+		ctx_attrs := make([]slog.Attr, 0, len(keys))
+
+		for _, key := range keys {
+			ctx_attrs = append(ctx_attrs, expand_attr(slog.Any(key, e.Info.Context[key])))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "context", Value: slog.GroupValue(ctx_attrs...)})
+	}
+
+	if len(e.Info.Suggestions) > 0 {
+		attrs = append(attrs, slog.Any("suggestions", e.Suggestions()))
+	}
+
+	if e.Info.Inner != nil {
+		attrs = append(attrs, slog.Attr{Key: "cause", Value: error_value(e.Info.Inner)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// error_value converts an error into a slog.Value.
+//
+// Parameters:
+//   - err: The error to convert. Assumed to be non-nil.
+//
+// Returns:
+//   - slog.Value: The value of the error.
+//
+// *Err values use LogValue. Errors that wrap several errors are expanded
+// into a group of causes and other errors are expanded into a group with
+// their message and their cause, if any.
+func error_value(err error) slog.Value {
+	switch e := err.(type) {
+	case *Err:
+		return e.LogValue()
+	case interface{ Unwrap() []error }:
+		var attrs []slog.Attr
+
+		for i, sub := range e.Unwrap() {
+			if sub == nil {
+				continue
+			}
+
+			attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: error_value(sub)})
+		}
+
+		return slog.GroupValue(
+			slog.String("message", err.Error()),
+			slog.Attr{Key: "causes", Value: slog.GroupValue(attrs...)},
+		)
+	case interface{ Unwrap() error }:
+		inner := e.Unwrap()
+		if inner == nil {
+			return slog.GroupValue(slog.String("message", err.Error()))
+		}
+
+		return slog.GroupValue(
+			slog.String("message", err.Error()),
+			slog.Attr{Key: "cause", Value: error_value(inner)},
+		)
+	default:
+		return slog.GroupValue(slog.String("message", err.Error()))
+	}
+}
+
+// expand_attr expands any error found in the attribute.
+//
+// Parameters:
+//   - attr: The attribute to expand.
+//
+// Returns:
+//   - slog.Attr: The expanded attribute.
+func expand_attr(attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := attr.Value.Any().(error)
+		if ok && err != nil {
+			return slog.Attr{Key: attr.Key, Value: error_value(err)}
+		}
+
+		if attr.Value.Kind() == slog.KindLogValuer {
+			return expand_attr(slog.Attr{Key: attr.Key, Value: attr.Value.Resolve()})
+		}
+	case slog.KindGroup:
+		group := attr.Value.Group()
+
+		attrs := make([]slog.Attr, 0, len(group))
+
+		for _, sub := range group {
+			attrs = append(attrs, expand_attr(sub))
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(attrs...)}
+	}
+
+	return attr
+}
+
+// max_severity returns the highest severity level of the *Err values found
+// in the attribute.
+//
+// Parameters:
+//   - attr: The attribute to inspect.
+//
+// Returns:
+//   - SeverityLevel: The highest severity level.
+//   - bool: True if an *Err was found, false otherwise.
+func max_severity(attr slog.Attr) (SeverityLevel, bool) {
+	switch attr.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := attr.Value.Any().(error)
+		if !ok {
+			return 0, false
+		}
+
+		e, ok := As(err)
+		if !ok || e == nil {
+			return 0, false
+		}
+
+		return e.Severity, true
+	case slog.KindGroup:
+		var level SeverityLevel
+		var found bool
+
+		for _, sub := range attr.Value.Group() {
+			sub_level, ok := max_severity(sub)
+			if ok && (!found || sub_level > level) {
+				level = sub_level
+				found = true
+			}
+		}
+
+		return level, found
+	}
+
+	return 0, false
+}
+
+// SlogHandler is a slog.Handler that expands error-valued attributes into
+// groups before passing the record to the wrapped handler.
+type SlogHandler struct {
+	// handler is the wrapped handler.
+	handler slog.Handler
+}
+
+// NewSlogHandler creates a new SlogHandler.
+//
+// Parameters:
+//   - handler: The handler to wrap.
+//
+// Returns:
+//   - *SlogHandler: A pointer to the new SlogHandler.
+//   - error: An error if the handler is nil.
+//
+// Errors:
+//   - *Err with code BadParameter: If the handler is nil.
+func NewSlogHandler(handler slog.Handler) (*SlogHandler, error) {
+	if handler == nil {
+		return nil, NewErrNilParameter("NewSlogHandler()", "handler")
+	}
+
+	return &SlogHandler{
+		handler: handler,
+	}, nil
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements the slog.Handler interface.
+//
+// Every error-valued attribute, including those nested in groups, is
+// expanded. If the record contains *Err values whose severity maps to a
+// higher slog.Level than the one of the record, the level of the record is
+// raised accordingly.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	level := record.Level
+
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+
+	record.Attrs(func(attr slog.Attr) bool {
+		severity, ok := max_severity(attr)
+		if ok && severity.SlogLevel() > level {
+			level = severity.SlogLevel()
+		}
+
+		attrs = append(attrs, expand_attr(attr))
+
+		return true
+	})
+
+	expanded := slog.NewRecord(record.Time, level, record.Message, record.PC)
+	expanded.AddAttrs(attrs...)
+
+	return h.handler.Handle(ctx, expanded)
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		expanded = append(expanded, expand_attr(attr))
+	}
+
+	return &SlogHandler{
+		handler: h.handler.WithAttrs(expanded),
+	}
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{
+		handler: h.handler.WithGroup(name),
+	}
+}
@@ -0,0 +1,301 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/slogtest"
+)
+
+// json_logger returns a logger that writes JSON records through a
+// SlogHandler to buf.
+func json_logger(t *testing.T, buf *bytes.Buffer) *slog.Logger {
+	t.Helper()
+
+	h, err := NewSlogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+	if err != nil {
+		t.Fatalf("NewSlogHandler() = %v", err)
+	}
+
+	return slog.New(h)
+}
+
+// decode_records decodes the JSON records written to buf.
+func decode_records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	for _, line := range bytes.Split(buf.Bytes(), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		var m map[string]any
+
+		err := json.Unmarshal(line, &m)
+		if err != nil {
+			t.Fatalf("invalid JSON record %q: %v", line, err)
+		}
+
+		records = append(records, m)
+	}
+
+	return records
+}
+
+// lookup returns the value at the given path of nested JSON objects.
+func lookup(m map[string]any, path ...string) any {
+	var value any = m
+
+	for _, key := range path {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = obj[key]
+	}
+
+	return value
+}
+
+func TestSlogLevel(t *testing.T) {
+	tests := []struct {
+		level SeverityLevel
+		want  slog.Level
+	}{
+		{INFO, slog.LevelInfo},
+		{WARNING, slog.LevelWarn},
+		{ERROR, slog.LevelError},
+		{FATAL, slog.LevelError + 4},
+		{SeverityLevel(42), slog.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := tt.level.SlogLevel(); got != tt.want {
+				t.Errorf("SlogLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	disable_stack_capture(t)
+
+	err := NewWithSeverity(WARNING, NoSuchKey, "missing")
+	err.AddContext("key", "foo")
+	err.AddContext("cause", fmt.Errorf("plain"))
+	err.AddSuggestion("Check the spelling of the key")
+	err.SetInner(fmt.Errorf("lookup: %w", New(BadParameter, "bad")))
+
+	var buf bytes.Buffer
+
+	json_logger(t, &buf).Info("failed", "err", err)
+
+	records := decode_records(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("records = %v, want 1", records)
+	}
+
+	tests := []struct {
+		path []string
+		want any
+	}{
+		{[]string{"level"}, "WARN"},
+		{[]string{"err", "severity"}, "WARNING"},
+		{[]string{"err", "code", "id"}, "errors.NoSuchKey"},
+		{[]string{"err", "code", "name"}, "NoSuchKey"},
+		{[]string{"err", "code", "int"}, float64(NoSuchKey)},
+		{[]string{"err", "message"}, "missing"},
+		{[]string{"err", "context", "key"}, "foo"},
+		{[]string{"err", "context", "cause", "message"}, "plain"},
+		{[]string{"err", "suggestions"}, []any{"Check the spelling of the key"}},
+		{[]string{"err", "cause", "message"}, "lookup: [ERROR] BadParameter: bad"},
+		{[]string{"err", "cause", "cause", "severity"}, "ERROR"},
+		{[]string{"err", "cause", "cause", "message"}, "bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.path, "."), func(t *testing.T) {
+			if got := lookup(records[0], tt.path...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %#v, want %#v", strings.Join(tt.path, "."), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogValueNil(t *testing.T) {
+	var err *Err
+
+	if got := err.LogValue(); got.String() != "" {
+		t.Errorf("LogValue() = %q, want an empty string", got)
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	disable_stack_capture(t)
+
+	fatal := NewWithSeverity(FATAL, OperationFail, "fatal")
+	info := NewWithSeverity(INFO, OperationFail, "info")
+
+	tests := []struct {
+		name  string
+		log   func(l *slog.Logger)
+		level string
+		path  []string
+		want  any
+	}{
+		{
+			name:  "raises the level",
+			log:   func(l *slog.Logger) { l.Info("msg", "err", New(BadParameter, "bad")) },
+			level: "ERROR",
+			path:  []string{"err", "message"},
+			want:  "bad",
+		},
+		{
+			name:  "raises the level to fatal",
+			log:   func(l *slog.Logger) { l.Warn("msg", "err", fatal) },
+			level: "ERROR+4",
+			path:  []string{"err", "severity"},
+			want:  "FATAL",
+		},
+		{
+			name:  "keeps a higher level",
+			log:   func(l *slog.Logger) { l.Error("msg", "err", info) },
+			level: "ERROR",
+			path:  []string{"err", "severity"},
+			want:  "INFO",
+		},
+		{
+			name:  "wrapped",
+			log:   func(l *slog.Logger) { l.Info("msg", "err", fmt.Errorf("wrap: %w", fatal)) },
+			level: "ERROR+4",
+			path:  []string{"err", "cause", "message"},
+			want:  "fatal",
+		},
+		{
+			name:  "nested groups",
+			log:   func(l *slog.Logger) { l.Info("msg", slog.Group("a", slog.Group("b", "err", fatal))) },
+			level: "ERROR+4",
+			path:  []string{"a", "b", "err", "code", "id"},
+			want:  "errors.OperationFail",
+		},
+		{
+			name:  "foreign error",
+			log:   func(l *slog.Logger) { l.Info("msg", "err", fmt.Errorf("plain")) },
+			level: "INFO",
+			path:  []string{"err", "message"},
+			want:  "plain",
+		},
+		{
+			name:  "joined errors",
+			log:   func(l *slog.Logger) { l.Debug("msg", "err", NewAggregate(info, fmt.Errorf("plain"))) },
+			level: "INFO",
+			path:  []string{"err", "causes", "1", "message"},
+			want:  "plain",
+		},
+		{
+			name:  "with attrs",
+			log:   func(l *slog.Logger) { l.With("err", fatal).Info("msg") },
+			level: "INFO",
+			path:  []string{"err", "message"},
+			want:  "fatal",
+		},
+		{
+			name:  "with group",
+			log:   func(l *slog.Logger) { l.WithGroup("g").Info("msg", "err", info) },
+			level: "INFO",
+			path:  []string{"g", "err", "severity"},
+			want:  "INFO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			tt.log(json_logger(t, &buf))
+
+			records := decode_records(t, &buf)
+			if len(records) != 1 {
+				t.Fatalf("records = %v, want 1", records)
+			}
+
+			if got := records[0][slog.LevelKey]; got != tt.level {
+				t.Errorf("level = %v, want %v", got, tt.level)
+			}
+
+			if got := lookup(records[0], tt.path...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %#v, want %#v", strings.Join(tt.path, "."), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlogHandlerConformance(t *testing.T) {
+	var buf bytes.Buffer
+
+	h, err := NewSlogHandler(slog.NewJSONHandler(&buf, nil))
+	if err != nil {
+		t.Fatalf("NewSlogHandler() = %v", err)
+	}
+
+	err = slogtest.TestHandler(h, func() []map[string]any {
+		return decode_records(t, &buf)
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewSlogHandlerNil(t *testing.T) {
+	_, err := NewSlogHandler(nil)
+	if !Is(err, BadParameter) {
+		t.Errorf("NewSlogHandler(nil) = %v, want a BadParameter error", err)
+	}
+}
+
+// discard_handler is a slog.Handler that is only enabled at or above a
+// level.
+type discard_handler struct {
+	slog.Handler
+
+	// min is the minimum enabled level.
+	min slog.Level
+}
+
+// Enabled implements the slog.Handler interface.
+func (h discard_handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.min
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	h, err := NewSlogHandler(discard_handler{min: slog.LevelWarn})
+	if err != nil {
+		t.Fatalf("NewSlogHandler() = %v", err)
+	}
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Errorf("Enabled(Info) = true, want false")
+	}
+
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("Enabled(Error) = false, want true")
+	}
+}
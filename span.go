@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// Span is a region of a source file an error refers to.
+type Span = internal.Span
+
+// AddSpan adds a source span to the error. The first span added is the
+// primary one. Does nothing if the receiver is nil.
+//
+// Parameters:
+//   - span: The span to add.
+func (e *Err) AddSpan(span Span) {
+	if e == nil {
+		return
+	}
+
+	if e.Info == nil {
+		e.Info = internal.NewInfo()
+	}
+
+	e.Info.Spans = append(e.Info.Spans, span)
+}
+
+// Spans returns the source spans of the error.
+//
+// Returns:
+//   - []Span: A copy of the spans of the error. Nil if there are none.
+func (e *Err) Spans() []Span {
+	if e == nil || e.Info == nil || len(e.Info.Spans) == 0 {
+		return nil
+	}
+
+	spans := make([]Span, len(e.Info.Spans))
+	copy(spans, e.Info.Spans)
+
+	return spans
+}
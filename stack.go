@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// max_stack_depth is the maximum number of program counters captured
+// for a single error.
+const max_stack_depth int = 32
+
+// capture_disabled is true when stack capture has been disabled via
+// SetStackCapture.
+var capture_disabled atomic.Bool
+
+// SetStackCapture enables or disables the automatic capture of the
+// stack trace when an error is created. Capture is enabled by default.
+//
+// Parameters:
+//   - enabled: Whether new errors should capture their stack trace.
+//
+// Disabling capture is useful in hot paths where errors are created
+// frequently and the stack trace is not needed.
+func SetStackCapture(enabled bool) {
+	capture_disabled.Store(!enabled)
+}
+
+// StackCaptureEnabled checks whether new errors capture their stack trace.
+//
+// Returns:
+//   - bool: True if stack capture is enabled, false otherwise.
+func StackCaptureEnabled() bool {
+	return !capture_disabled.Load()
+}
+
+// capture_callers captures the program counters of the current goroutine.
+//
+// Parameters:
+//   - skip: The number of frames to skip, where 0 is the caller of
+//     capture_callers.
+//
+// Returns:
+//   - []uintptr: The program counters. Nil if stack capture is disabled.
+func capture_callers(skip int) []uintptr {
+	if capture_disabled.Load() {
+		return nil
+	}
+
+	var pcs [max_stack_depth]uintptr
+
+	n := runtime.Callers(skip+2, pcs[:])
+	if n == 0 {
+		return nil
+	}
+
+	callers := make([]uintptr, n)
+	copy(callers, pcs[:n])
+
+	return callers
+}
+
+// Frame is a resolved frame of a stack trace.
+type Frame struct {
+	// Function is the fully qualified name of the function.
+	Function string
+
+	// File is the path of the source file.
+	File string
+
+	// Line is the line number in the source file.
+	Line int
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Format:
+//
+//	<function> (<file>:<line>)
+func (f Frame) String() string {
+	return f.Function + " (" + f.File + ":" + strconv.Itoa(f.Line) + ")"
+}
+
+// Package returns the import path of the package of the function.
+//
+// Returns:
+//   - string: The import path. Empty if the function is unknown.
+func (f Frame) Package() string {
+	name := f.Function
+
+	slash := strings.LastIndexByte(name, '/')
+
+	dot := strings.IndexByte(name[slash+1:], '.')
+	if dot < 0 {
+		return ""
+	}
+
+	return name[:slash+1+dot]
+}
+
+// resolve_frames resolves the given program counters into frames.
+//
+// Parameters:
+//   - callers: The program counters to resolve.
+//
+// Returns:
+//   - []Frame: The resolved frames. Nil if there are none.
+func resolve_frames(callers []uintptr) []Frame {
+	if len(callers) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(callers)
+
+	var stack []Frame
+
+	for {
+		frame, more := frames.Next()
+
+		stack = append(stack, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
@@ -0,0 +1,142 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PlayerR9/go-errors/internal"
+)
+
+// NewTemplate creates a new error whose message is rendered from a template.
+//
+// Parameters:
+//   - code: The error code.
+//   - template: The message template.
+//   - args: The named arguments of the template.
+//
+// Returns:
+//   - *Err: A pointer to the new error. Never returns nil.
+//
+// Parameters of the template are written as {name} and are replaced by the
+// value of the argument with the same name, formatted with %v. {name:q}
+// formats the value with %q instead. Parameters without an argument are left
+// as-is.
+//
+// The arguments are stored apart from the context of the error (see
+// TemplateArgs), so that AddContext cannot change the message, and the
+// message is rendered each time it is needed.
+func NewTemplate[C ErrorCoder](code C, template string, args map[string]any) *Err {
+	err := &Err{
+		Severity: ERROR,
+		Code:     code,
+		Info:     internal.NewInfo(),
+	}
+
+	err.Info.Template = template
+	err.Info.Callers = capture_callers(1)
+
+	if len(args) > 0 {
+		err.Info.TemplateArgs = make(map[string]any, len(args))
+
+		for key, value := range args {
+			err.Info.TemplateArgs[key] = value
+		}
+	}
+
+	return err
+}
+
+// Template returns the message template of the error.
+//
+// Returns:
+//   - string: The message template. Empty if the error has none.
+func (e *Err) Template() string {
+	if e == nil || e.Info == nil {
+		return ""
+	}
+
+	return e.Info.Template
+}
+
+// TemplateArgs returns the named arguments of the message template of the
+// error.
+//
+// Returns:
+//   - map[string]any: A copy of the arguments. Nil if there are none.
+func (e *Err) TemplateArgs() map[string]any {
+	if e == nil || e.Info == nil || len(e.Info.TemplateArgs) == 0 {
+		return nil
+	}
+
+	args := make(map[string]any, len(e.Info.TemplateArgs))
+
+	for key, value := range e.Info.TemplateArgs {
+		args[key] = value
+	}
+
+	return args
+}
+
+// RenderMessage returns the message of the error, rendering its template
+// with its arguments if it has one.
+//
+// Returns:
+//   - string: The message of the error. Empty if the receiver is nil.
+func (e *Err) RenderMessage() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.Info == nil || e.Info.Template == "" {
+		return e.Message
+	}
+
+	return render_template(e.Info.Template, e.Info.TemplateArgs)
+}
+
+// render_template renders the template with the given arguments.
+//
+// Parameters:
+//   - template: The template to render.
+//   - args: The arguments of the template.
+//
+// Returns:
+//   - string: The rendered template.
+func render_template(template string, args map[string]any) string {
+	var b strings.Builder
+
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+
+		end += start
+
+		b.WriteString(template[:start])
+
+		name, verb := template[start+1:end], "%v"
+
+		if before, ok := strings.CutSuffix(name, ":q"); ok {
+			name, verb = before, "%q"
+		}
+
+		value, ok := args[name]
+		if ok {
+			fmt.Fprintf(&b, verb, value)
+		} else {
+			b.WriteString(template[start : end+1])
+		}
+
+		template = template[end+1:]
+	}
+
+	b.WriteString(template)
+
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRenderMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		args     map[string]any
+		want     string
+	}{
+		{"plain", "no parameters", nil, "no parameters"},
+		{"value", "got {n} items", map[string]any{"n": 3}, "got 3 items"},
+		{"quoted", "key {key:q} missing", map[string]any{"key": "foo"}, `key "foo" missing`},
+		{"missing argument", "key {key} missing", nil, "key {key} missing"},
+		{"unterminated", "key {key", map[string]any{"key": "foo"}, "key {key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTemplate(BadParameter, tt.template, tt.args).RenderMessage()
+			if got != tt.want {
+				t.Errorf("RenderMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateArgsApartFromContext(t *testing.T) {
+	err := NewErrNoSuchKey("TestTemplateArgsApartFromContext()", "foo")
+	err.AddContext("key", "user-supplied")
+
+	want := `[ERROR] NoSuchKey: key ("foo") does not exist`
+
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got := err.TemplateArgs()["key"]; got != "foo" {
+		t.Errorf("TemplateArgs()[key] = %v, want foo", got)
+	}
+
+	outer := New(OperationFail, "outer")
+	outer.AddContext("key", "outer value")
+
+	merged := MergeErrors(err, outer).(*Err)
+
+	if got := merged.Error(); got != want {
+		t.Errorf("Error() after MergeErrors = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateArgsNotDisplayedAsContext(t *testing.T) {
+	disable_stack_capture(t)
+
+	var buf bytes.Buffer
+
+	err := DisplayError(&buf, NewErrNoSuchKey("f()", "foo"))
+	if err != nil {
+		t.Fatalf("DisplayError() = %v", err)
+	}
+
+	if strings.Contains(buf.String(), "Context:") {
+		t.Errorf("DisplayError() shows the template arguments as context:\n%s", buf.String())
+	}
+}
+
+func TestTemplateArgsJSON(t *testing.T) {
+	err := NewTemplate(NoSuchKey, "key {key:q} in {table}", map[string]any{
+		"key":   "foo",
+		"table": "users",
+	})
+	err.AddContext("request_id", "abc")
+
+	data, m_err := json.Marshal(err)
+	if m_err != nil {
+		t.Fatalf("Marshal() = %v", m_err)
+	}
+
+	var doc map[string]any
+
+	_ = json.Unmarshal(data, &doc)
+
+	args, _ := doc["template_args"].(map[string]any)
+	if args["key"] != "foo" || args["table"] != "users" {
+		t.Errorf("template_args = %v, want key and table", doc["template_args"])
+	}
+
+	ctx, _ := doc["context"].(map[string]any)
+	if _, ok := ctx["key"]; ok || len(ctx) != 1 {
+		t.Errorf("context = %v, want only request_id", doc["context"])
+	}
+
+	var back Err
+
+	u_err := json.Unmarshal(data, &back)
+	if u_err != nil {
+		t.Fatalf("Unmarshal() = %v", u_err)
+	}
+
+	back.AddContext("key", "other")
+
+	if got, want := back.RenderMessage(), `key "foo" in users`; got != want {
+		t.Errorf("RenderMessage() after decoding = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateArgsSlog(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("failed", "err", NewErrNoSuchKey("f()", "foo"))
+
+	var doc struct {
+		Err struct {
+			Template     string         `json:"template"`
+			TemplateArgs map[string]any `json:"template_args"`
+			Context      map[string]any `json:"context"`
+		} `json:"err"`
+	}
+
+	err := json.Unmarshal(buf.Bytes(), &doc)
+	if err != nil {
+		t.Fatalf("invalid log line %q: %v", buf.String(), err)
+	}
+
+	if doc.Err.Template == "" || doc.Err.TemplateArgs["key"] != "foo" {
+		t.Errorf("log line = %s, want the template and its arguments", buf.String())
+	}
+
+	if doc.Err.Context != nil {
+		t.Errorf("log line = %s, want no context", buf.String())
+	}
+}